@@ -0,0 +1,236 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// assetFS is a read-only fs.FS over the embedded assets. Directories are
+// derived from the asset names.
+type assetFS struct {
+	dirs map[string][]string
+}
+
+var (
+	assetFSOnce sync.Once
+	assetFSRoot *assetFS
+)
+
+// AssetFS returns the embedded assets as a standard file system, so they
+// can be read with fs.ReadDir, fs.Stat, fs.Glob, fs.WalkDir and anything
+// else that accepts an fs.FS. golang-migrate v4.14 and later can read the
+// migrations from it with its generic source driver:
+// iofs.New(AssetFS(), migrationsDir).
+func AssetFS() fs.FS {
+	assetFSOnce.Do(func() {
+		dirs := map[string]map[string]bool{".": {}}
+		for _, name := range AssetNames() {
+			child := name
+			for dir := path.Dir(child); ; dir = path.Dir(dir) {
+				if dirs[dir] == nil {
+					dirs[dir] = map[string]bool{}
+				}
+				dirs[dir][path.Base(child)] = true
+				if dir == "." {
+					break
+				}
+				child = dir
+			}
+		}
+
+		assetFSRoot = &assetFS{dirs: map[string][]string{}}
+		for dir, children := range dirs {
+			names := make([]string, 0, len(children))
+			for name := range children {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			assetFSRoot.dirs[dir] = names
+		}
+	})
+	return assetFSRoot
+}
+
+func (f *assetFS) isAsset(name string) bool {
+	_, ok := _bindata_gz[name]
+	return ok
+}
+
+// Open implements fs.FS.
+func (f *assetFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if _, ok := f.dirs[name]; ok {
+		return &assetDir{fsys: f, name: name}, nil
+	}
+	if f.isAsset(name) {
+		return &assetFile{name: name}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// Stat implements fs.StatFS.
+func (f *assetFS) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	if _, ok := f.dirs[name]; ok {
+		return assetDirInfo{name: name}, nil
+	}
+	if f.isAsset(name) {
+		return AssetInfo(name)
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir implements fs.ReadDirFS. Entries are sorted by name.
+func (f *assetFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	children, ok := f.dirs[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, child := range children {
+		entries = append(entries, assetDirEntry{fsys: f, name: path.Join(name, child)})
+	}
+	return entries, nil
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (f *assetFS) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) || !f.isAsset(name) {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrNotExist}
+	}
+	return Asset(name)
+}
+
+// fileChecksum returns the hex SHA-256 of a file in fsys, reusing the
+// cached checksum when the file is an embedded asset.
+func fileChecksum(fsys fs.FS, name string) (string, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return "", err
+	}
+	if asset, ok := info.(*AssetFileInfo); ok {
+		return asset.Checksum(), nil
+	}
+	f, err := fsys.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// assetFile streams an asset, decompressing on first read.
+type assetFile struct {
+	name   string
+	reader io.ReadCloser
+}
+
+func (f *assetFile) Stat() (fs.FileInfo, error) {
+	return AssetInfo(f.name)
+}
+
+func (f *assetFile) Read(p []byte) (int, error) {
+	if f.reader == nil {
+		reader, err := AssetReader(f.name)
+		if err != nil {
+			return 0, &fs.PathError{Op: "read", Path: f.name, Err: err}
+		}
+		f.reader = reader
+	}
+	return f.reader.Read(p)
+}
+
+func (f *assetFile) Close() error {
+	if f.reader == nil {
+		return nil
+	}
+	return f.reader.Close()
+}
+
+// assetDir is an open directory. ReadDir pages through its entries.
+type assetDir struct {
+	fsys   *assetFS
+	name   string
+	offset int
+}
+
+func (d *assetDir) Stat() (fs.FileInfo, error) {
+	return assetDirInfo{name: d.name}, nil
+}
+
+func (d *assetDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+
+func (d *assetDir) Close() error {
+	return nil
+}
+
+func (d *assetDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries, err := d.fsys.ReadDir(d.name)
+	if err != nil {
+		return nil, err
+	}
+	entries = entries[d.offset:]
+	if n > 0 {
+		if len(entries) == 0 {
+			return nil, io.EOF
+		}
+		if len(entries) > n {
+			entries = entries[:n]
+		}
+	}
+	d.offset += len(entries)
+	return entries, nil
+}
+
+type assetDirInfo struct {
+	name string
+}
+
+func (i assetDirInfo) Name() string       { return path.Base(i.name) }
+func (i assetDirInfo) Size() int64        { return 0 }
+func (i assetDirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0555 }
+func (i assetDirInfo) ModTime() time.Time { return time.Time{} }
+func (i assetDirInfo) IsDir() bool        { return true }
+func (i assetDirInfo) Sys() interface{}   { return nil }
+
+type assetDirEntry struct {
+	fsys *assetFS
+	name string
+}
+
+func (e assetDirEntry) Name() string { return path.Base(e.name) }
+
+func (e assetDirEntry) IsDir() bool {
+	_, ok := e.fsys.dirs[e.name]
+	return ok
+}
+
+func (e assetDirEntry) Type() fs.FileMode {
+	if e.IsDir() {
+		return fs.ModeDir
+	}
+	return 0
+}
+
+func (e assetDirEntry) Info() (fs.FileInfo, error) {
+	return e.fsys.Stat(e.name)
+}
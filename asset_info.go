@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AssetReader streams the asset for the given name without decompressing
+// it into memory. The caller must close the returned reader.
+func AssetReader(name string) (io.ReadCloser, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if data, ok := _bindata_gz[canonicalName]; ok {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("Read %q: %v", name, err)
+		}
+		return gz, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// AssetFileInfo describes an embedded asset. It implements os.FileInfo.
+type AssetFileInfo struct {
+	name     string
+	size     int64
+	modTime  time.Time
+	checksum string
+}
+
+func (fi *AssetFileInfo) Name() string       { return path.Base(fi.name) }
+func (fi *AssetFileInfo) Size() int64        { return fi.size }
+func (fi *AssetFileInfo) Mode() os.FileMode  { return 0444 }
+func (fi *AssetFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *AssetFileInfo) IsDir() bool        { return false }
+func (fi *AssetFileInfo) Sys() interface{}   { return nil }
+
+// Checksum returns the hex encoded SHA-256 of the decompressed asset.
+func (fi *AssetFileInfo) Checksum() string { return fi.checksum }
+
+var (
+	_bindata_info_mu    sync.Mutex
+	_bindata_info_cache = map[string]*AssetFileInfo{}
+)
+
+// AssetInfo returns the size, modification time and checksum of the asset
+// for the given name. The asset is streamed through the hash once and the
+// result cached. The modification time comes from the gzip header and is
+// zero for assets generated without metadata.
+func AssetInfo(name string) (*AssetFileInfo, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	_bindata_info_mu.Lock()
+	defer _bindata_info_mu.Unlock()
+	if fi, ok := _bindata_info_cache[canonicalName]; ok {
+		return fi, nil
+	}
+
+	data, ok := _bindata_gz[canonicalName]
+	if !ok {
+		return nil, fmt.Errorf("AssetInfo %s not found", name)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	defer gz.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, gz)
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	fi := &AssetFileInfo{
+		name:     canonicalName,
+		size:     size,
+		modTime:  gz.ModTime,
+		checksum: hex.EncodeToString(hash.Sum(nil)),
+	}
+	_bindata_info_cache[canonicalName] = fi
+	return fi, nil
+}
+
+// _bindata_gz holds the compressed bytes of each asset in myfile.go, mapped
+// to its name. Add an entry here when an asset is added to myfile.go.
+var _bindata_gz = map[string][]byte{
+	"migrations/10_add_default_match_isactive.down.sql":                _migrations_10_add_default_match_isactive_down_sql,
+	"migrations/10_add_default_match_isactive.up.sql":                  _migrations_10_add_default_match_isactive_up_sql,
+	"migrations/11_add_default_match_isautogenerated.down.sql":         _migrations_11_add_default_match_isautogenerated_down_sql,
+	"migrations/11_add_default_match_isautogenerated.up.sql":           _migrations_11_add_default_match_isautogenerated_up_sql,
+	"migrations/12_add_is_feed_active.down.sql":                        _migrations_12_add_is_feed_active_down_sql,
+	"migrations/12_add_is_feed_active.up.sql":                          _migrations_12_add_is_feed_active_up_sql,
+	"migrations/13_add_pool_config.down.sql":                           _migrations_13_add_pool_config_down_sql,
+	"migrations/13_add_pool_config.up.sql":                             _migrations_13_add_pool_config_up_sql,
+	"migrations/14_add_resetoken_user.up.sql":                          _migrations_14_add_resetoken_user_up_sql,
+	"migrations/14_add_resettoken_user.down.sql":                       _migrations_14_add_resettoken_user_down_sql,
+	"migrations/15_citext_user_email.down.sql":                         _migrations_15_citext_user_email_down_sql,
+	"migrations/15_citext_user_email.up.sql":                           _migrations_15_citext_user_email_up_sql,
+	"migrations/16_add_audits.down.sql":                                _migrations_16_add_audits_down_sql,
+	"migrations/16_add_audits.up.sql":                                  _migrations_16_add_audits_up_sql,
+	"migrations/17_add_format_to_matches.down.sql":                     _migrations_17_add_format_to_matches_down_sql,
+	"migrations/17_add_format_to_matches.up.sql":                       _migrations_17_add_format_to_matches_up_sql,
+	"migrations/18_add_over_under_defaults.down.sql":                   _migrations_18_add_over_under_defaults_down_sql,
+	"migrations/18_add_over_under_defaults.up.sql":                     _migrations_18_add_over_under_defaults_up_sql,
+	"migrations/19_add_threshold_to_legs.down.sql":                     _migrations_19_add_threshold_to_legs_down_sql,
+	"migrations/19_add_threshold_to_legs.up.sql":                       _migrations_19_add_threshold_to_legs_up_sql,
+	"migrations/1_add_uuid_extension.down.sql":                         _migrations_1_add_uuid_extension_down_sql,
+	"migrations/1_add_uuid_extension.up.sql":                           _migrations_1_add_uuid_extension_up_sql,
+	"migrations/20_add_colossus_status_to_match.down.sql":              _migrations_20_add_colossus_status_to_match_down_sql,
+	"migrations/20_add_colossus_status_to_match.up.sql":                _migrations_20_add_colossus_status_to_match_up_sql,
+	"migrations/21_remove_colossus_status_on_leg.down.sql":             _migrations_21_remove_colossus_status_on_leg_down_sql,
+	"migrations/21_remove_colossus_status_on_leg.up.sql":               _migrations_21_remove_colossus_status_on_leg_up_sql,
+	"migrations/22_rename_status_to_internal_status_on_match.down.sql": _migrations_22_rename_status_to_internal_status_on_match_down_sql,
+	"migrations/22_rename_status_to_internal_status_on_match.up.sql":   _migrations_22_rename_status_to_internal_status_on_match_up_sql,
+	"migrations/23_addconsolationprizes.down.sql":                      _migrations_23_addconsolationprizes_down_sql,
+	"migrations/23_addconsolationprizes.up.sql":                        _migrations_23_addconsolationprizes_up_sql,
+	"migrations/24_add_team_ou_scores_to_matches.down.sql":             _migrations_24_add_team_ou_scores_to_matches_down_sql,
+	"migrations/24_add_team_ou_scores_to_matches.up.sql":               _migrations_24_add_team_ou_scores_to_matches_up_sql,
+	"migrations/25_add_colossus_match.down.sql":                        _migrations_25_add_colossus_match_down_sql,
+	"migrations/25_add_colossus_match.up.sql":                          _migrations_25_add_colossus_match_up_sql,
+	"migrations/26_remove_colossus_state_from_match.down.sql":          _migrations_26_remove_colossus_state_from_match_down_sql,
+	"migrations/26_remove_colossus_state_from_match.up.sql":            _migrations_26_remove_colossus_state_from_match_up_sql,
+	"migrations/27_pool_default_scaling.down.sql":                      _migrations_27_pool_default_scaling_down_sql,
+	"migrations/27_pool_default_scaling.up.sql":                        _migrations_27_pool_default_scaling_up_sql,
+	"migrations/2_initialize_schema.down.sql":                          _migrations_2_initialize_schema_down_sql,
+	"migrations/2_initialize_schema.up.sql":                            _migrations_2_initialize_schema_up_sql,
+	"migrations/30_competitors.down.sql":                               _migrations_30_competitors_down_sql,
+	"migrations/30_competitors_up.sql":                                 _migrations_30_competitors_up_sql,
+	"migrations/32_add_pool_status_transitions.down.sql":               _migrations_32_add_pool_status_transitions_down_sql,
+	"migrations/32_add_pool_status_transitions.up.sql":                 _migrations_32_add_pool_status_transitions_up_sql,
+	"migrations/33_add_leg_results.down.sql":                           _migrations_33_add_leg_results_down_sql,
+	"migrations/33_add_leg_results.up.sql":                             _migrations_33_add_leg_results_up_sql,
+	"migrations/34_add_over_under_to_leg_results.down.sql":             _migrations_34_add_over_under_to_leg_results_down_sql,
+	"migrations/34_add_over_under_to_leg_results.up.sql":               _migrations_34_add_over_under_to_leg_results_up_sql,
+	"migrations/35_add_fantasy_legs.down.sql":                          _migrations_35_add_fantasy_legs_down_sql,
+	"migrations/35_add_fantasy_legs.up.sql":                            _migrations_35_add_fantasy_legs_up_sql,
+	"migrations/36_add_pool_series.down.sql":                           _migrations_36_add_pool_series_down_sql,
+	"migrations/36_add_pool_series.up.sql":                             _migrations_36_add_pool_series_up_sql,
+	"migrations/37_add_over_under_cutoffs.down.sql":                    _migrations_37_add_over_under_cutoffs_down_sql,
+	"migrations/37_add_over_under_cutoffs.up.sql":                      _migrations_37_add_over_under_cutoffs_up_sql,
+	"migrations/38_add_pool_void_rules.down.sql":                       _migrations_38_add_pool_void_rules_down_sql,
+	"migrations/38_add_pool_void_rules.up.sql":                         _migrations_38_add_pool_void_rules_up_sql,
+	"migrations/39_add_reserve_legs.down.sql":                          _migrations_39_add_reserve_legs_down_sql,
+	"migrations/39_add_reserve_legs.up.sql":                            _migrations_39_add_reserve_legs_up_sql,
+	"migrations/3_add_foreign_key_indicies.down.sql":                   _migrations_3_add_foreign_key_indicies_down_sql,
+	"migrations/3_add_foreign_key_indicies.up.sql":                     _migrations_3_add_foreign_key_indicies_up_sql,
+	"migrations/40_add_currency_rates.down.sql":                        _migrations_40_add_currency_rates_down_sql,
+	"migrations/40_add_currency_rates.up.sql":                          _migrations_40_add_currency_rates_up_sql,
+	"migrations/41_add_pool_templates.down.sql":                        _migrations_41_add_pool_templates_down_sql,
+	"migrations/41_add_pool_templates.up.sql":                          _migrations_41_add_pool_templates_up_sql,
+	"migrations/42_recreate_competitors.down.sql":                      _migrations_42_recreate_competitors_down_sql,
+	"migrations/42_recreate_competitors.up.sql":                        _migrations_42_recreate_competitors_up_sql,
+	"migrations/4_add_user_roles.down.sql":                             _migrations_4_add_user_roles_down_sql,
+	"migrations/4_add_user_roles.up.sql":                               _migrations_4_add_user_roles_up_sql,
+	"migrations/5_add_email_unique_constaint_on_user.down.sql":         _migrations_5_add_email_unique_constaint_on_user_down_sql,
+	"migrations/5_add_email_unique_constaint_on_user.up.sql":           _migrations_5_add_email_unique_constaint_on_user_up_sql,
+	"migrations/6_add_guarantee_carry_in_allocation_to_pool.down.sql":  _migrations_6_add_guarantee_carry_in_allocation_to_pool_down_sql,
+	"migrations/6_add_guarantee_carry_in_allocation_to_pool.up.sql":    _migrations_6_add_guarantee_carry_in_allocation_to_pool_up_sql,
+	"migrations/7_add_note_to_pool.down.sql":                           _migrations_7_add_note_to_pool_down_sql,
+	"migrations/7_add_note_to_pool.up.sql":                             _migrations_7_add_note_to_pool_up_sql,
+	"migrations/8_add_pool_currency_columns.down.sql":                  _migrations_8_add_pool_currency_columns_down_sql,
+	"migrations/8_add_pool_currency_columns.up.sql":                    _migrations_8_add_pool_currency_columns_up_sql,
+	"migrations/9_remove_pool_null_constraints.down.sql":               _migrations_9_remove_pool_null_constraints_down_sql,
+	"migrations/9_remove_pool_null_constraints.up.sql":                 _migrations_9_remove_pool_null_constraints_up_sql,
+	"migrations/migration-data.go":                                     _migrations_migration_data_go,
+	"seeds/default/1_pool_defaults.sql":                                _seeds_default_1_pool_defaults_sql,
+	"seeds/default/2_over_under_defaults.sql":                          _seeds_default_2_over_under_defaults_sql,
+	"seeds/default/3_fantasy_scoring_rules.sql":                        _seeds_default_3_fantasy_scoring_rules_sql,
+	"seeds/default/4_over_under_cutoffs.sql":                           _seeds_default_4_over_under_cutoffs_sql,
+	"seeds/default/5_pool_void_rules.sql":                              _seeds_default_5_pool_void_rules_sql,
+	"seeds/default/6_currencies.sql":                                   _seeds_default_6_currencies_sql,
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestAssetInfoCoversEveryAsset(t *testing.T) {
+	names := AssetNames()
+	if len(_bindata_gz) != len(names) {
+		t.Errorf("_bindata_gz has %d entries, want %d", len(_bindata_gz), len(names))
+	}
+	for _, name := range names {
+		data, err := Asset(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fi, err := AssetInfo(name)
+		if err != nil {
+			t.Errorf("AssetInfo(%q): %v", name, err)
+			continue
+		}
+		if fi.Size() != int64(len(data)) {
+			t.Errorf("AssetInfo(%q) size = %d, want %d", name, fi.Size(), len(data))
+		}
+		r, err := AssetReader(name)
+		if err != nil {
+			t.Errorf("AssetReader(%q): %v", name, err)
+			continue
+		}
+		streamed, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil || string(streamed) != string(data) {
+			t.Errorf("AssetReader(%q) does not match Asset", name)
+		}
+	}
+}
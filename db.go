@@ -0,0 +1,95 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// dbConfig holds the connection settings shared with the service containers.
+type dbConfig struct {
+	Host     string `env:"POSTGRES_HOST" envDefault:"localhost"`
+	Port     int    `env:"POSTGRES_PORT" envDefault:"5432"`
+	User     string `env:"POSTGRES_USER"`
+	Password string `env:"POSTGRES_PASSWORD"`
+	Name     string `env:"POSTGRES_DB"`
+	SSLMode  string `env:"POSTGRES_SSLMODE" envDefault:"disable"`
+}
+
+func (c dbConfig) url() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     fmt.Sprintf("%s:%d", c.Host, c.Port),
+		Path:     c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+func loadDBConfig() (dbConfig, error) {
+	var cfg dbConfig
+	err := env.Parse(&cfg)
+	return cfg, errors.Wrap(err, "parsing database config")
+}
+
+func (c dbConfig) open() (*sql.DB, error) {
+	db, err := sql.Open("postgres", c.url())
+	if err != nil {
+		return nil, errors.Wrap(err, "opening database")
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "connecting to %s on %s", c.Name, c.Host)
+	}
+	return db, nil
+}
+
+// openDB connects to the database described by the POSTGRES_* environment.
+func openDB() (*sql.DB, error) {
+	cfg, err := loadDBConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.open()
+}
+
+// withScratchDatabase creates an empty database on the configured server,
+// runs fn against it and drops it again afterwards.
+func withScratchDatabase(fn func(db *sql.DB) error) error {
+	cfg, err := loadDBConfig()
+	if err != nil {
+		return err
+	}
+	admin, err := cfg.open()
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	scratch := cfg
+	scratch.Name = fmt.Sprintf("%s_scratch_%d", cfg.Name, time.Now().UnixNano())
+	quoted := pq.QuoteIdentifier(scratch.Name)
+	if _, err := admin.Exec("CREATE DATABASE " + quoted); err != nil {
+		return errors.Wrapf(err, "creating scratch database %s", scratch.Name)
+	}
+	log.WithField("database", scratch.Name).Info("created scratch database")
+	defer func() {
+		if _, err := admin.Exec("DROP DATABASE IF EXISTS " + quoted); err != nil {
+			log.WithError(err).WithField("database", scratch.Name).Error("dropping scratch database")
+		}
+	}()
+
+	db, err := scratch.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return fn(db)
+}
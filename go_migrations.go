@@ -0,0 +1,49 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// GoMigration is a migration written in Go for data changes that are
+// awkward in SQL. It shares the version table, transaction handling and
+// logging of the SQL assets and is ordered among them by Version.
+type GoMigration struct {
+	Version uint
+	Name    string
+	Up      func(q querier) error
+	Down    func(q querier) error
+	// NoTransaction runs the functions directly against the database, for
+	// work that must commit in batches.
+	NoTransaction bool
+}
+
+// goMigrations is filled by registerGoMigration from init functions.
+var goMigrations []*GoMigration
+
+// registerGoMigration adds a Go migration to every registry built afterwards.
+// Call it from an init function in a file named after the migration, e.g.
+// migration_31_backfill_competitor_match.go.
+func registerGoMigration(m *GoMigration) {
+	goMigrations = append(goMigrations, m)
+}
+
+// file wraps one direction of the migration. Go code cannot be hashed
+// meaningfully, so the checksum covers the version, name and direction and
+// only changes when the migration is renamed.
+func (g *GoMigration) file(direction Direction) *MigrationFile {
+	fn := g.Up
+	if direction == DirectionDown {
+		fn = g.Down
+	}
+	asset := fmt.Sprintf("go:%d_%s.%s", g.Version, g.Name, direction)
+	sum := sha256.Sum256([]byte(asset))
+	return &MigrationFile{
+		Asset:         asset,
+		Direction:     direction,
+		Checksum:      hex.EncodeToString(sum[:]),
+		fn:            fn,
+		noTransaction: g.NoTransaction,
+	}
+}
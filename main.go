@@ -0,0 +1,242 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/caarlos0/env"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// command is a single migrate subcommand.
+type command struct {
+	summary string
+	run     func(registry *Registry, args []string) error
+}
+
+var commands = map[string]command{
+	"list":     {summary: "list the embedded migrations with their checksums", run: runList},
+	"plan":     {summary: "print the SQL that up (or down to -target) would run, without running it", run: runPlan},
+	"seed":     {summary: "upsert the default seeds and those of -env, without changing the schema version", run: runSeed},
+	"snapshot": {summary: "migrate a scratch database and export its schema as JSON and SQL", run: runSnapshot},
+	"up":       {summary: "apply all pending migrations", run: runUp},
+	"verify":   {summary: "report applied migrations whose embedded SQL has changed", run: runVerify},
+}
+
+func main() {
+	registry, err := NewRegistry(AssetFS(), migrationsDir)
+	if err != nil {
+		log.WithError(err).Fatal("refusing to start")
+	}
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+	if err := cmd.run(registry, os.Args[2:]); err != nil {
+		log.WithError(err).Fatalf("migrate %s failed", os.Args[1])
+	}
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: migrate <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	w := tabwriter.NewWriter(os.Stderr, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\t%s\n", name, commands[name].summary)
+	}
+	w.Flush()
+}
+
+// newMigrator creates a migrator configured from the MIGRATION_* environment.
+func newMigrator(db *sql.DB, registry *Registry) (*Migrator, error) {
+	var cfg migratorConfig
+	if err := env.Parse(&cfg); err != nil {
+		return nil, errors.Wrap(err, "parsing migrator config")
+	}
+	migrator := NewMigrator(db, registry)
+	migrator.LockTimeout = cfg.LockTimeout
+	return migrator, nil
+}
+
+func runList(registry *Registry, args []string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tUP SHA-256\tDOWN SHA-256")
+	for _, m := range registry.Migrations() {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", m.Version, m.Name, m.Up.Checksum, m.Down.Checksum)
+	}
+	return w.Flush()
+}
+
+func runUp(registry *Registry, args []string) error {
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := newMigrator(db, registry)
+	if err != nil {
+		return err
+	}
+	return migrator.Up()
+}
+
+func runVerify(registry *Registry, args []string) error {
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := newMigrator(db, registry)
+	if err != nil {
+		return err
+	}
+	drifts, err := migrator.Verify()
+	if err != nil {
+		return err
+	}
+	if len(drifts) == 0 {
+		fmt.Println("all applied migrations match the embedded assets")
+		return nil
+	}
+
+	var changed int
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tRECORDED\tEMBEDDED")
+	for _, d := range drifts {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", d.Version, d.Name, d.Status, d.Recorded, d.Embedded)
+		if d.Status != DriftUnrecorded {
+			changed++
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if changed > 0 {
+		return errors.Errorf("%d applied migrations differ from the embedded assets", changed)
+	}
+	return nil
+}
+
+func runPlan(registry *Registry, args []string) error {
+	flags := flag.NewFlagSet("plan", flag.ExitOnError)
+	target := flags.Int("target", -1, "version to migrate to, defaults to the latest embedded version")
+	flags.Parse(args)
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := newMigrator(db, registry)
+	if err != nil {
+		return err
+	}
+	current, dirty, applied, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		log.WithField("version", current).Warn("database is dirty, the plan assumes this version fully applied")
+	}
+	to := registry.Latest()
+	if *target >= 0 {
+		to = uint(*target)
+		if _, ok := registry.Lookup(to); !ok && to != 0 {
+			return errors.Errorf("version %d is not embedded", to)
+		}
+	}
+
+	steps, err := registry.Plan(current, applied, to)
+	if err != nil {
+		return err
+	}
+	if applied {
+		fmt.Printf("-- current version %d, target version %d\n", current, to)
+	} else {
+		fmt.Printf("-- no migrations applied, target version %d\n", to)
+	}
+	writePlan(os.Stdout, steps)
+	return nil
+}
+
+func runSnapshot(registry *Registry, args []string) error {
+	flags := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	jsonPath := flags.String("json", "db-schema.json", "file to write the JSON snapshot to")
+	sqlPath := flags.String("sql", "db-schema.sql", "file to write the SQL snapshot to")
+	flags.Parse(args)
+
+	return withScratchDatabase(func(db *sql.DB) error {
+		migrator, err := newMigrator(db, registry)
+		if err != nil {
+			return err
+		}
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+		snapshot, err := takeSnapshot(db)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(*jsonPath, snapshot.WriteJSON); err != nil {
+			return err
+		}
+		return writeFile(*sqlPath, snapshot.WriteSQL)
+	})
+}
+
+func writeFile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "creating %s", path)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "writing %s", path)
+	}
+	return errors.Wrapf(f.Close(), "closing %s", path)
+}
+
+func runSeed(registry *Registry, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	environment := flags.String("env", os.Getenv("SEED_ENV"), fmt.Sprintf("seed set to apply after %q, one of %v", defaultSeedSet, SeedSets(AssetFS())))
+	flags.Parse(args)
+
+	seeds, err := LoadSeeds(AssetFS(), *environment)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := newMigrator(db, registry)
+	if err != nil {
+		return err
+	}
+	return migrator.Seed(seeds)
+}
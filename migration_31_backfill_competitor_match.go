@@ -0,0 +1,82 @@
+package main
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	registerGoMigration(&GoMigration{
+		Version: 31,
+		Name:    "backfill_competitor_match",
+		Up:      backfillCompetitorMatch,
+		Down:    func(q querier) error { return nil },
+	})
+}
+
+// backfillCompetitorMatch copies teams written after 30_competitors into
+// competitors and competitor_match. Competitors keep the id of the team they
+// came from, as in 30_competitors. The down is a no-op: the copied rows are
+// indistinguishable from ones the feed wrote itself. Databases that never
+// ran 30_competitors have nothing to backfill yet; 42_recreate_competitors
+// creates and fills the tables for them.
+func backfillCompetitorMatch(q querier) error {
+	var exists bool
+	if err := q.QueryRow(`SELECT to_regclass('public.competitor_match') IS NOT NULL`).Scan(&exists); err != nil {
+		return errors.Wrap(err, "looking up competitor_match")
+	}
+	if !exists {
+		log.Warn("competitor_match does not exist, leaving the backfill to 42_recreate_competitors")
+		return nil
+	}
+
+	rows, err := q.Query(`
+		SELECT t.id, t.external_id, t.name, t.logo, t.match_id,
+			EXISTS (SELECT 1 FROM competitors c WHERE c.id = t.id)
+		FROM teams t
+		WHERE t.match_id IS NOT NULL AND NOT EXISTS (
+			SELECT 1 FROM competitor_match cm WHERE cm.match_id = t.match_id AND cm.competitor_id = t.id
+		)
+		ORDER BY t.match_id, t.id`)
+	if err != nil {
+		return errors.Wrap(err, "reading unlinked teams")
+	}
+
+	type team struct {
+		id, matchID            string
+		externalID, name, logo sql.NullString
+		hasCompetitor          bool
+	}
+	var teams []team
+	for rows.Next() {
+		var t team
+		if err := rows.Scan(&t.id, &t.externalID, &t.name, &t.logo, &t.matchID, &t.hasCompetitor); err != nil {
+			rows.Close()
+			return errors.Wrap(err, "reading unlinked teams")
+		}
+		teams = append(teams, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "reading unlinked teams")
+	}
+
+	var created int
+	for _, t := range teams {
+		if !t.hasCompetitor {
+			_, err := q.Exec(`INSERT INTO competitors (id, external_id, name, logo) VALUES ($1, $2, $3, $4)`,
+				t.id, t.externalID, t.name, t.logo)
+			if err != nil {
+				return errors.Wrapf(err, "creating competitor for team %s", t.id)
+			}
+			created++
+		}
+		if _, err := q.Exec(`INSERT INTO competitor_match (match_id, competitor_id) VALUES ($1, $2)`, t.matchID, t.id); err != nil {
+			return errors.Wrapf(err, "linking team %s to match %s", t.id, t.matchID)
+		}
+	}
+	log.WithFields(log.Fields{"competitors": created, "links": len(teams)}).Info("backfilled competitor_match from teams")
+	return nil
+}
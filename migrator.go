@@ -0,0 +1,408 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// The version table keeps the golang-migrate layout so existing databases
+// can be taken over without a conversion step.
+const createVersionTableSQL = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT NOT NULL PRIMARY KEY,
+    dirty BOOLEAN NOT NULL
+)`
+
+const createChecksumTableSQL = `
+CREATE TABLE IF NOT EXISTS schema_migrations_checksums (
+    version BIGINT NOT NULL PRIMARY KEY,
+    checksum TEXT NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+)`
+
+const addAppliedByColumnSQL = `
+ALTER TABLE schema_migrations_checksums ADD COLUMN IF NOT EXISTS applied_by TEXT`
+
+// migrationLockKey is the advisory lock every instance takes before migrating.
+const migrationLockKey = 4107365122
+
+const lockPollInterval = time.Second
+
+// noTransactionRegexp matches statements Postgres refuses to run inside a
+// transaction block.
+var noTransactionRegexp = regexp.MustCompile(`(?is)\bCONCURRENTLY\b|\bVACUUM\b|\b(?:CREATE|DROP)\s+DATABASE\b|\bALTER\s+SYSTEM\b|\bALTER\s+TYPE\b[^;]*\bADD\s+VALUE\b`)
+
+// migratorConfig holds the environment settings for running migrations.
+type migratorConfig struct {
+	LockTimeout time.Duration `env:"MIGRATION_LOCK_TIMEOUT" envDefault:"5m"`
+}
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Migrator applies embedded migrations to a database.
+type Migrator struct {
+	db       *sql.DB
+	registry *Registry
+
+	// LockTimeout bounds how long Up waits for another instance holding
+	// the migration lock.
+	LockTimeout time.Duration
+	// Owner names this instance in logs and in schema_migrations_checksums.
+	Owner string
+}
+
+// NewMigrator creates a migrator for the registry's migrations, owned by
+// the current host name (the pod name under Kubernetes).
+func NewMigrator(db *sql.DB, registry *Registry) *Migrator {
+	owner, err := os.Hostname()
+	if err != nil {
+		owner = "unknown"
+	}
+	return &Migrator{
+		db:          db,
+		registry:    registry,
+		LockTimeout: 5 * time.Minute,
+		Owner:       owner,
+	}
+}
+
+func (m *Migrator) ensureTables() error {
+	for _, stmt := range []string{createVersionTableSQL, createChecksumTableSQL, addAppliedByColumnSQL} {
+		if _, err := m.db.Exec(stmt); err != nil {
+			return errors.Wrap(err, "creating migration tables")
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) tableExists(name string) (bool, error) {
+	var exists bool
+	err := m.db.QueryRow(`SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists)
+	return exists, errors.Wrapf(err, "looking up table %s", name)
+}
+
+// Version returns the current schema version without modifying the
+// database. ok is false when no migration has been applied yet.
+func (m *Migrator) Version() (version uint, dirty bool, ok bool, err error) {
+	exists, err := m.tableExists("schema_migrations")
+	if err != nil || !exists {
+		return 0, false, false, err
+	}
+	err = m.db.QueryRow(`SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, false, nil
+	}
+	if err != nil {
+		return 0, false, false, errors.Wrap(err, "reading schema version")
+	}
+	return version, dirty, true, nil
+}
+
+func setVersion(q querier, version uint, dirty bool) error {
+	if _, err := q.Exec(`TRUNCATE schema_migrations`); err != nil {
+		return errors.Wrap(err, "clearing schema version")
+	}
+	_, err := q.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, version, dirty)
+	return errors.Wrap(err, "writing schema version")
+}
+
+func (m *Migrator) recordChecksum(q querier, version uint, checksum string) error {
+	_, err := q.Exec(`
+		INSERT INTO schema_migrations_checksums (version, checksum, applied_by) VALUES ($1, $2, $3)
+		ON CONFLICT (version) DO UPDATE
+		SET checksum = EXCLUDED.checksum, applied_by = EXCLUDED.applied_by, applied_at = now()`,
+		version, checksum, m.Owner)
+	return errors.Wrapf(err, "recording checksum for version %d", version)
+}
+
+func (m *Migrator) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "starting transaction")
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "committing transaction")
+}
+
+// lock takes the migration advisory lock on a dedicated connection, polling
+// until LockTimeout expires. The returned func releases it.
+func (m *Migrator) lock() (func(), error) {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "reserving lock connection")
+	}
+
+	logger := log.WithField("pod", m.Owner)
+	deadline := time.Now().Add(m.LockTimeout)
+	for waited := false; ; waited = true {
+		var acquired bool
+		if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, migrationLockKey).Scan(&acquired); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "taking migration lock")
+		}
+		if acquired {
+			logger.Info("acquired migration lock")
+			break
+		}
+		if time.Now().After(deadline) {
+			conn.Close()
+			return nil, errors.Errorf("timed out after %s waiting for the migration lock", m.LockTimeout)
+		}
+		if !waited {
+			logger.WithField("timeout", m.LockTimeout).Info("another instance is migrating, waiting for the migration lock")
+		}
+		time.Sleep(lockPollInterval)
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+			logger.WithError(err).Error("releasing migration lock")
+		}
+		conn.Close()
+	}, nil
+}
+
+// Up applies every embedded migration above the current version while
+// holding the migration lock. It refuses to run against a dirty database or
+// one whose applied migrations have drifted.
+func (m *Migrator) Up() error {
+	unlock, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Create the bookkeeping tables only once locked, so concurrent pods do
+	// not race on the catalog.
+	if err := m.ensureTables(); err != nil {
+		return err
+	}
+
+	// Read the version only once locked, another pod may have just migrated.
+	current, dirty, ok, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return errors.Errorf("database is dirty at version %d, fix it by hand before migrating", current)
+	}
+	if err := m.checkDrift(); err != nil {
+		return err
+	}
+
+	var applied int
+	for _, migration := range m.registry.Migrations() {
+		if ok && migration.Version <= current {
+			continue
+		}
+		if err := m.run(migration, DirectionUp); err != nil {
+			return err
+		}
+		applied++
+	}
+	log.WithFields(log.Fields{"pod": m.Owner, "applied": applied, "version": m.registry.Latest()}).Info("database is up to date")
+	return nil
+}
+
+// run executes one half of a migration and moves the schema version. When
+// the migration allows it the body and the version update share a
+// transaction, so a failure leaves the database untouched instead of dirty.
+func (m *Migrator) run(migration *Migration, direction Direction) error {
+	file := migration.File(direction)
+	body, transactional, err := file.body()
+	if err != nil {
+		return err
+	}
+	logger := log.WithFields(log.Fields{
+		"version":   migration.Version,
+		"name":      migration.Name,
+		"direction": direction,
+		"pod":       m.Owner,
+	})
+
+	finish := func(q querier) error {
+		if direction == DirectionUp {
+			if err := setVersion(q, migration.Version, false); err != nil {
+				return err
+			}
+			return m.recordChecksum(q, migration.Version, file.Checksum)
+		}
+		if _, err := q.Exec(`DELETE FROM schema_migrations_checksums WHERE version = $1`, migration.Version); err != nil {
+			return errors.Wrapf(err, "removing checksum for version %d", migration.Version)
+		}
+		if previous, ok := m.registry.Previous(migration.Version); ok {
+			return setVersion(q, previous.Version, false)
+		}
+		_, err := q.Exec(`TRUNCATE schema_migrations`)
+		return errors.Wrap(err, "clearing schema version")
+	}
+
+	started := time.Now()
+	if transactional {
+		logger.Info("running migration in a transaction")
+		err = m.inTx(func(tx *sql.Tx) error {
+			if err := body(tx); err != nil {
+				return errors.Wrapf(err, "running %s", file.Asset)
+			}
+			return finish(tx)
+		})
+	} else {
+		logger.Warn("migration cannot run in a transaction, marking the database dirty while it runs")
+		err = m.inTx(func(tx *sql.Tx) error {
+			return setVersion(tx, migration.Version, true)
+		})
+		if err == nil {
+			if err = body(m.db); err != nil {
+				err = errors.Wrapf(err, "running %s", file.Asset)
+			}
+		}
+		if err == nil {
+			err = m.inTx(func(tx *sql.Tx) error { return finish(tx) })
+		}
+	}
+	if err != nil {
+		return err
+	}
+	logger.WithField("duration", time.Since(started)).Info("migration finished")
+	return nil
+}
+
+// DriftStatus describes how an applied migration compares to its embedded asset.
+type DriftStatus string
+
+const (
+	// DriftMismatch means the embedded SQL changed after it was applied.
+	DriftMismatch DriftStatus = "mismatch"
+	// DriftUnrecorded means the version was applied before checksums were tracked.
+	DriftUnrecorded DriftStatus = "unrecorded"
+	// DriftMissing means the database has a version that is no longer embedded.
+	DriftMissing DriftStatus = "missing"
+)
+
+// Drift is an applied version whose checksum does not match the embedded asset.
+type Drift struct {
+	Version  uint
+	Name     string
+	Status   DriftStatus
+	Recorded string
+	Embedded string
+}
+
+// Verify compares the recorded checksum of every applied version with the
+// embedded up migration.
+func (m *Migrator) Verify() ([]Drift, error) {
+	current, _, ok, err := m.Version()
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	recorded, err := m.recordedChecksums()
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []Drift
+	for _, migration := range m.registry.Migrations() {
+		if migration.Version > current {
+			break
+		}
+		checksum, found := recorded[migration.Version]
+		delete(recorded, migration.Version)
+		switch {
+		case !found:
+			drifts = append(drifts, Drift{
+				Version:  migration.Version,
+				Name:     migration.Name,
+				Status:   DriftUnrecorded,
+				Embedded: migration.Up.Checksum,
+			})
+		case checksum != migration.Up.Checksum:
+			drifts = append(drifts, Drift{
+				Version:  migration.Version,
+				Name:     migration.Name,
+				Status:   DriftMismatch,
+				Recorded: checksum,
+				Embedded: migration.Up.Checksum,
+			})
+		}
+	}
+	for version, checksum := range recorded {
+		if version > current {
+			continue
+		}
+		drifts = append(drifts, Drift{Version: version, Status: DriftMissing, Recorded: checksum})
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Version < drifts[j].Version
+	})
+	return drifts, nil
+}
+
+func (m *Migrator) recordedChecksums() (map[uint]string, error) {
+	recorded := map[uint]string{}
+	exists, err := m.tableExists("schema_migrations_checksums")
+	if err != nil || !exists {
+		return recorded, err
+	}
+
+	rows, err := m.db.Query(`SELECT version, checksum FROM schema_migrations_checksums`)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading migration checksums")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version uint
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, errors.Wrap(err, "reading migration checksums")
+		}
+		recorded[version] = checksum
+	}
+	return recorded, errors.Wrap(rows.Err(), "reading migration checksums")
+}
+
+// checkDrift fails on changed or vanished migrations. Versions applied before
+// checksums were tracked are adopted with their current embedded checksum.
+func (m *Migrator) checkDrift() error {
+	drifts, err := m.Verify()
+	if err != nil {
+		return err
+	}
+	var changed int
+	for _, d := range drifts {
+		if d.Status != DriftUnrecorded {
+			log.WithFields(log.Fields{
+				"version":  d.Version,
+				"status":   d.Status,
+				"recorded": d.Recorded,
+				"embedded": d.Embedded,
+			}).Error("applied migration differs from embedded asset")
+			changed++
+			continue
+		}
+		log.WithField("version", d.Version).Warn("no checksum recorded, adopting embedded checksum")
+		if err := m.recordChecksum(m.db, d.Version, d.Embedded); err != nil {
+			return err
+		}
+	}
+	if changed > 0 {
+		return errors.Errorf("%d applied migrations differ from the embedded assets, run migrate verify", changed)
+	}
+	return nil
+}
@@ -25,7 +25,7 @@ func bindata_read(data []byte, name string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var _migrations_10_add_default_match_isactive_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\x4d\x2c\x49\xce\x48\x2d\x56\x80\x88\x39\xfb\xfb\x84\xfa\xfa\x29\x64\x16\xc7\x27\x26\x97\x64\x96\xa5\x2a\xb8\x04\xf9\x07\x28\xf8\xf9\x87\x28\xf8\x85\xfa\xf8\x58\x03\x02\x00\x00\xff\xff\x3e\x90\xff\x34\x39\x00\x00\x00")
+var _migrations_10_add_default_match_isactive_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x73\x00\x8c\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x6d\x61\x74\x63\x68\x65\x73\x20\x41\x4c\x54\x45\x52\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x69\x73\x5f\x61\x63\x74\x69\x76\x65\x20\x44\x52\x4f\x50\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x3b\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x6d\x61\x74\x63\x68\x65\x73\x20\x41\x4c\x54\x45\x52\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x69\x73\x5f\x61\x63\x74\x69\x76\x65\x20\x44\x52\x4f\x50\x20\x44\x45\x46\x41\x55\x4c\x54\x3b\x0a\x03\x00\xaf\x72\x2f\x27\x73\x00\x00\x00")
 
 func migrations_10_add_default_match_isactive_down_sql() ([]byte, error) {
 	return bindata_read(
@@ -43,7 +43,7 @@ func migrations_10_add_default_match_isactive_up_sql() ([]byte, error) {
 	)
 }
 
-var _migrations_11_add_default_match_isautogenerated_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\x4d\x2c\x49\xce\x48\x2d\x56\x80\x88\x39\xfb\xfb\x84\xfa\xfa\x29\x64\x16\xc7\x27\x96\x96\xe4\xa7\xa7\xe6\xa5\x16\x25\x96\xa4\xa6\x28\xb8\x04\xf9\x07\x28\xf8\xf9\x87\x28\xf8\x85\xfa\xf8\x58\x03\x02\x00\x00\xff\xff\xb0\x5d\xaa\xd5\x40\x00\x00\x00")
+var _migrations_11_add_default_match_isautogenerated_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\x4d\x2c\x49\xce\x48\x2d\x56\x80\x88\x39\xfb\xfb\x84\xfa\xfa\x29\x64\x16\xc7\x27\x96\x96\xe4\xa7\xa7\xe6\xa5\x16\x25\x96\xa4\xa6\x28\xb8\x04\xf9\x07\x28\xf8\xf9\x87\x28\xf8\x85\xfa\xf8\x58\x73\x91\x69\x80\x8b\xab\x9b\x63\xa8\x4f\x88\x35\x17\x60\x00\x8b\xa6\xd3\x87\x81\x00\x00\x00")
 
 func migrations_11_add_default_match_isautogenerated_down_sql() ([]byte, error) {
 	return bindata_read(
@@ -79,7 +79,7 @@ func migrations_12_add_is_feed_active_up_sql() ([]byte, error) {
 	)
 }
 
-var _migrations_13_add_pool_config_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc8\xcf\xcf\x89\x4f\xce\xcf\x4b\xcb\x4c\x2f\x8e\x2f\xcd\xcb\x2c\x2c\x4d\xcd\x4b\x2d\x2e\x8e\xcf\xcf\x8b\xcf\x49\x4d\x4f\xce\x2f\xcd\x2b\x89\x4f\x4f\xcc\x4d\x8d\x2f\xa9\x2c\x48\xb5\xe6\x02\x9b\x11\xe2\xe8\xe4\xe3\x8a\xc3\x0c\x6b\x40\x00\x00\x00\xff\xff\xb5\x83\x52\x1f\x66\x00\x00\x00")
+var _migrations_13_add_pool_config_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x69\x00\x96\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x6f\x6f\x6c\x5f\x64\x65\x66\x61\x75\x6c\x74\x73\x5f\x75\x6e\x69\x71\x75\x65\x6e\x65\x73\x73\x5f\x6f\x6e\x5f\x6c\x65\x67\x63\x6f\x75\x6e\x74\x5f\x67\x61\x6d\x65\x5f\x74\x79\x70\x65\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x6f\x6f\x6c\x5f\x64\x65\x66\x61\x75\x6c\x74\x73\x3b\x0a\x03\x00\xbc\x44\xb1\x3f\x69\x00\x00\x00")
 
 func migrations_13_add_pool_config_down_sql() ([]byte, error) {
 	return bindata_read(
@@ -538,6 +538,258 @@ func migrations_migration_data_go() ([]byte, error) {
 	)
 }
 
+var _seeds_default_1_pool_defaults_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x97\x5f\x6f\xda\x3c\x14\xc6\xef\xfd\x29\x9e\x3b\x5a\xc9\x54\x01\xbd\xad\x5e\x69\xda\x05\x23\xa6\x45\x8d\x92\x8a\x84\x6a\xbb\xb2\x32\x38\xcd\xa2\x05\x9b\x25\xce\x3a\xbe\xfd\x14\x5a\x4a\x12\x68\xbb\x4a\x0e\x08\x09\x11\x1f\x3f\xbf\xd8\xe7\x8f\xf4\xf4\xfb\xb8\xd3\x3a\xc3\x92\x1e\xe2\x32\x33\x05\xd6\x94\x23\x89\x57\xc4\x61\x36\x6b\x42\xac\x96\xc8\x28\xc1\x42\x97\xca\x5c\xe0\x96\x36\xb4\x84\x56\xac\xdf\xc7\x5a\xeb\x4c\xee\xf6\xc9\x52\xa5\xbf\x4a\x52\x54\x14\x52\x2b\x99\x51\xb2\xdd\x21\x2b\x29\x59\x29\x71\x14\x1a\x39\xf5\xf3\x52\xa9\x54\x25\xc8\xa9\x20\x53\xc0\xfc\xa0\x4a\x6b\xa5\x15\x6d\x50\x90\x31\xa9\x4a\x0a\xe8\x87\x6a\xa1\x20\xe4\xfa\xb1\x80\xd1\xd5\x3f\xfc\x8e\xb3\x92\x0a\x7c\xa7\x4c\x3f\x5e\xb0\xa9\x1f\x8a\x59\x84\xa9\x1f\x05\xcd\x37\xc1\x59\x46\x89\xdc\xd2\x79\xed\x24\x1c\x49\x19\xe7\xb1\x32\x44\x1c\x8b\x38\xcf\x37\x32\x55\x1c\x71\x96\xe9\x45\x6c\x52\xad\x38\x4a\x95\x1a\xb9\xa5\x70\xac\x52\x55\x1d\xc9\xc8\x35\xe5\x32\x4b\x15\x71\xac\xe2\x3f\x07\x8f\xea\x51\x26\x5d\xfc\x24\xd3\x8a\xdb\x3d\x5c\x94\x79\x4e\x6a\xb1\xe1\x50\xda\xd0\x39\xbb\x1f\x79\x73\x11\x32\x9c\xfd\xc7\xd1\x1b\x07\x73\x3f\x12\x33\x19\x46\xb3\xe9\xad\x90\xd7\x5e\xf0\x65\xe4\xc9\x60\x32\x11\x7e\x38\xbd\x17\x3d\x8e\xde\xcd\xf0\xa6\xc7\x31\x74\x1c\x0e\x87\x63\xc0\x31\x78\xfe\xd9\x7e\x2f\x9d\xe7\x0f\x47\x2f\x8c\x66\xd5\x86\xb8\x34\x3a\x21\x45\x79\x6c\x68\xb9\x4b\x6f\xef\x9c\x33\x9c\x5d\x7d\x04\x79\x69\x05\xf9\xff\x47\x90\x03\xc7\x0a\x73\xe0\x7c\x04\x3a\xb4\x03\xfd\xc7\x74\x06\xf7\x62\x26\xe7\xbe\x2b\x66\xa7\xce\x6a\x83\x7c\xd2\xe4\x36\xc8\x27\xce\x71\x83\x3d\x74\x4e\x79\xe1\x93\x91\x1f\x8d\xc2\x6f\x4f\xb7\x7d\xca\x33\xef\xc1\x03\xc7\x0e\xb9\x2a\x6e\x37\x88\x46\x72\xd8\xe8\x1b\x0b\xca\x57\x47\x94\xed\x15\x67\x5b\xd9\x66\xf1\xb5\xb5\x2d\xce\x91\xbd\x74\xbb\x7c\xad\xdf\x78\x47\x53\xe1\x15\x40\x37\xf7\xdf\x55\x8f\xef\x09\xdd\xb4\xf2\x31\x7d\x9b\x1d\xeb\x89\xd1\xf5\x5c\xc8\x60\x22\x3d\x71\x2d\x7c\x37\x6c\x14\xab\x05\xc8\xd5\xdb\x10\x7b\xe5\xf4\x06\xc4\x66\x49\xbd\x81\xb1\xd8\xdd\x47\x29\xed\x1a\xee\x32\x3b\x1d\xf5\xfc\xfb\xac\xce\x73\xd5\xd5\x24\x38\x0a\xeb\x66\x28\xbc\x83\xb2\x31\x1f\x58\xe0\x63\x1c\xf8\x13\x6f\x3a\x8e\x5e\x31\x4d\xe7\x70\x03\xcc\xef\xdc\x51\x24\x10\x8a\x88\x61\x6f\xa2\xf0\x19\xe2\xeb\xd8\x9b\xbb\xc2\xbd\xd8\x3b\x2b\x86\x17\x6f\x55\x0f\x78\xf1\x5b\x0c\x35\xc7\x55\x8f\xa8\xf9\x30\x86\x9a\x13\xab\xc7\xd4\xfc\x19\xc3\xa1\x43\xab\x87\x1e\xda\x37\x86\xa6\x31\x3b\xd8\xd1\x5e\x3c\x60\x3c\x59\xb9\x57\x29\xcf\x4e\xaf\xcd\x39\xb2\xeb\x70\xb9\x62\xed\x1c\x62\x3d\xf4\xc5\x35\x32\x6c\x7d\x63\x7d\x4d\x69\x43\x9f\xd8\xdf\x01\x00\xc7\x86\x98\xc2\xc0\x0f\x00\x00")
+
+func seeds_default_1_pool_defaults_sql() ([]byte, error) {
+	return bindata_read(
+		_seeds_default_1_pool_defaults_sql,
+		"seeds/default/1_pool_defaults.sql",
+	)
+}
+
+var _seeds_default_2_over_under_defaults_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x93\xd1\x6a\xdb\x30\x14\x86\xef\xf5\x14\xe7\xce\x0d\x38\x59\xe2\x28\xad\xcd\xd8\x85\x17\xcb\xc1\xd4\x58\x23\x96\xcb\xee\x84\x36\x9f\x34\x83\x46\xda\x64\xd9\xb0\xb7\x1f\x32\xa5\x35\x69\xe9\x68\xc9\x8d\x0d\xe2\x7c\x9f\xf4\xc3\x7f\xe6\x73\xe0\x03\xda\x4f\xbd\x6e\xd1\x82\x3b\x5a\xec\x8e\xe6\xa1\xed\xe0\x37\x5a\xb8\x57\x27\x04\xa5\x5b\x38\x29\xf7\xf3\x08\x07\x63\x4f\xca\x2d\xe0\x16\xff\x62\x0b\x46\x93\xf9\x1c\xcc\x80\x56\x8e\xb0\x6c\xf1\xa0\xfa\x07\xd7\xc9\x5e\xff\xfa\xd3\xa3\xc6\xae\x93\x46\x4b\x2f\x91\xa3\x40\x3e\x0a\x48\x51\xd5\x6c\x2f\xa0\xa8\x04\x7f\x4d\x00\x57\x9e\x09\x61\x0a\x85\x80\x03\x6a\xf9\xf4\xc0\x10\x0e\x6a\x30\x16\xdb\xe9\x91\x36\x0e\x67\xe4\x2e\x2d\x1b\x56\x13\xb8\x0a\x32\x2e\x52\x19\x05\x21\x04\x3f\x8c\xff\x25\x74\xb1\x09\x81\xde\xf8\x6f\xa0\x7a\x67\xee\x51\xa3\x55\x0e\x5b\x78\xbc\x3b\x98\x85\xe7\xe0\x3a\x08\x61\x45\x57\x9e\x49\xe8\xbb\xc8\x8d\x27\xe3\x78\xb1\x79\x12\xbc\x89\x6e\x79\x53\x09\xb6\x97\xb5\xd8\x17\xb7\x4c\xee\x4a\xfe\x35\x2d\x25\xcf\x73\x56\xd5\xc5\x1d\x7b\x8e\xb1\x8e\x96\xa3\xf3\x7a\x79\x19\xa7\x4f\x48\xe3\x51\xb6\x8e\x2e\xe4\xf4\xd9\xe3\xe5\x28\xbb\xa6\xff\x77\x96\x2c\xdd\x35\x4c\xf2\x5c\x96\x6c\xc7\xaa\xac\x7e\x4e\x4b\x13\x4f\x47\xf4\xa3\x0e\x9f\xee\x66\xed\x69\x9a\x7c\xd4\xe1\xd3\xac\xa2\xc8\xe3\x49\xfc\x96\x84\xf0\x0a\xb6\xbc\xca\xcb\x62\x2b\x5e\xab\xf1\x0c\x32\x0e\xcd\xb7\x2c\x15\x0c\x6a\x26\x08\x9c\xd5\x1a\xbe\x00\xfb\xbe\x2d\x9b\x8c\x65\x8b\xb3\xc2\x13\x78\x59\xf9\xe9\xf8\xcb\x7d\x20\x30\x6e\xc4\x74\x48\x1b\x87\x9f\xc9\xbf\x01\x00\x8d\x87\x3c\x38\xf5\x03\x00\x00")
+
+func seeds_default_2_over_under_defaults_sql() ([]byte, error) {
+	return bindata_read(
+		_seeds_default_2_over_under_defaults_sql,
+		"seeds/default/2_over_under_defaults.sql",
+	)
+}
+
+var _migrations_32_add_pool_status_transitions_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\x41\x4f\x83\x30\x18\x86\xef\xfd\x15\xef\x6d\x90\xec\xe8\x6d\xa7\x0a\xdf\xb4\x91\x75\x0b\x94\xc8\xbc\x34\x18\xaa\x69\x22\xd4\xb4\x45\xff\xbe\x19\x10\xa2\x59\x34\x1e\xdb\x2f\xef\xf3\xb4\xef\x97\x95\xc4\x15\x41\xf1\xdb\x82\x20\xf6\x90\x47\x05\x6a\x44\xa5\x2a\xbc\x3b\xf7\xa6\x43\x6c\xe3\x18\x74\xf4\xed\x10\x6c\xb4\x6e\x08\x48\x18\x00\xd8\x0e\x75\x2d\xf2\x29\x20\xeb\xa2\xc0\xa9\x14\x07\x5e\x9e\xf1\x40\x67\xe4\xb4\xe7\x75\xa1\x30\x8e\xb6\xd3\xaf\x66\x30\xbe\x8d\x46\x7f\xdc\x24\xe9\x76\x0a\x4f\xe8\x2b\x42\x49\x7b\x2a\x49\x66\x34\xbb\x03\x12\xdb\xa5\x38\x4a\xe4\x54\x90\x22\x64\xbc\xca\x78\x4e\x33\xe2\xc5\xbb\x7e\x79\x1d\x14\x35\x6a\xc5\xcc\xe3\xe8\xfe\x18\x8e\xc1\xf8\x55\xff\xcd\x7a\xb9\x9f\xad\x33\xc4\x9b\x36\xb8\xe1\x27\x61\xfd\xdb\x66\xb3\x98\x6c\x6f\xa0\xc4\x81\x2a\xc5\x0f\x27\x3c\x0a\x75\x3f\x1d\xf1\x74\x94\x74\x1d\x1b\xdc\x67\x92\xb2\x74\xc7\xd8\x52\xbd\x90\x39\x35\xff\xab\x5e\x2f\xbd\xe9\x68\x7b\xc3\x70\xa9\xe6\xb7\x25\xd5\x95\x90\x77\x78\x8e\xde\x18\x24\x4b\x6c\x8b\x68\x7b\x93\xee\xd8\xd7\x00\xa1\xd5\x3a\xad\xf5\x01\x00\x00")
+
+func migrations_32_add_pool_status_transitions_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_32_add_pool_status_transitions_up_sql,
+		"migrations/32_add_pool_status_transitions.up.sql",
+	)
+}
+
+var _migrations_32_add_pool_status_transitions_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x2e\x00\xd1\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x6f\x6f\x6c\x5f\x73\x74\x61\x74\x75\x73\x5f\x74\x72\x61\x6e\x73\x69\x74\x69\x6f\x6e\x73\x3b\x0a\x03\x00\x54\x0c\x17\xed\x2e\x00\x00\x00")
+
+func migrations_32_add_pool_status_transitions_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_32_add_pool_status_transitions_down_sql,
+		"migrations/32_add_pool_status_transitions.down.sql",
+	)
+}
+
+var _migrations_33_add_leg_results_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\x8e\x41\x6a\xc3\x30\x10\x45\xf7\x3e\xc5\x5f\xda\xd0\x1b\x74\xa5\xda\xdf\x54\x54\x96\x83\x3c\xa2\x49\x37\xa6\x54\xa2\x08\x5c\x1b\x22\x85\x5c\xbf\xa0\x40\xbb\x9c\xf7\x66\x1e\xd3\x3b\x2a\x21\x44\xbd\x18\x42\x8f\xb0\xb3\x80\x67\xbd\xc8\x82\x2d\x7e\xaf\xd7\x98\x6f\x5b\xc9\x68\x1b\x00\x95\xa4\x00\xef\xf5\x50\x17\xad\x37\x06\x27\xa7\x27\xe5\x2e\x78\xe3\x05\x8e\x23\x1d\x6d\xcf\x7a\x9d\xd1\xa6\xd0\x61\xb6\x18\x68\x28\x44\xaf\x96\x5e\x0d\x7c\xaa\xb1\xe3\x56\xbe\x8e\x9f\x08\xe1\x59\xfe\x6a\x0f\x75\x4f\xfb\x1e\xaf\x6b\x0a\x55\x3e\x58\x8e\xa5\x6c\x31\xac\x9f\x05\xa2\x27\x2e\xa2\xa6\x13\xde\xb5\xbc\xd6\x11\x1f\xb3\xe5\xff\x4f\x03\x47\xe5\x8d\x60\x3f\xee\x6d\xd7\x74\xcf\xcd\xef\x00\x3d\x29\x3e\x48\xe7\x00\x00\x00")
+
+func migrations_33_add_leg_results_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_33_add_leg_results_up_sql,
+		"migrations/33_add_leg_results.up.sql",
+	)
+}
+
+var _migrations_33_add_leg_results_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x22\x00\xdd\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x65\x67\x5f\x72\x65\x73\x75\x6c\x74\x73\x3b\x0a\x03\x00\x0d\x08\xe5\x10\x22\x00\x00\x00")
+
+func migrations_33_add_leg_results_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_33_add_leg_results_down_sql,
+		"migrations/33_add_leg_results.down.sql",
+	)
+}
+
+var _migrations_34_add_over_under_to_leg_results_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x74\x00\x8b\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x6c\x65\x67\x5f\x72\x65\x73\x75\x6c\x74\x73\x0a\x20\x20\x20\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x74\x6f\x74\x61\x6c\x20\x44\x45\x43\x49\x4d\x41\x4c\x2c\x0a\x20\x20\x20\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x74\x68\x72\x65\x73\x68\x6f\x6c\x64\x20\x44\x45\x43\x49\x4d\x41\x4c\x3b\x0a\x03\x00\xdc\xc5\x02\x8d\x74\x00\x00\x00")
+
+func migrations_34_add_over_under_to_leg_results_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_34_add_over_under_to_leg_results_up_sql,
+		"migrations/34_add_over_under_to_leg_results.up.sql",
+	)
+}
+
+var _migrations_34_add_over_under_to_leg_results_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5e\x00\xa1\xff\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x6c\x65\x67\x5f\x72\x65\x73\x75\x6c\x74\x73\x0a\x20\x20\x20\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x74\x6f\x74\x61\x6c\x2c\x0a\x20\x20\x20\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x74\x68\x72\x65\x73\x68\x6f\x6c\x64\x3b\x0a\x03\x00\x1a\x46\xe9\xf8\x5e\x00\x00\x00")
+
+func migrations_34_add_over_under_to_leg_results_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_34_add_over_under_to_leg_results_down_sql,
+		"migrations/34_add_over_under_to_leg_results.down.sql",
+	)
+}
+
+var _migrations_35_add_fantasy_legs_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x90\xcf\x6a\x32\x31\x14\xc5\xf7\x79\x8a\xb3\x1c\xc1\xe5\xb7\x73\x95\x6f\xe6\x5a\x42\x63\xa6\x1d\x13\xd0\x55\x48\x6b\x3a\x04\x6c\xc6\x4e\x32\x05\xdf\xbe\x04\xd1\x6a\x6b\xb7\xf7\x70\x7f\xe7\x0f\x97\x9a\x3a\x68\xfe\x5f\x12\xf6\xbe\x4f\xe0\x4d\x83\xba\x95\x66\xa5\x20\x96\x50\xad\x06\x6d\xc4\x5a\xaf\x71\xd8\xbb\xa3\x1f\x6d\xd8\xc1\x18\xd1\xa0\xa3\x25\x75\xa4\x6a\x3a\x2b\x09\x55\xd8\xcd\x16\x8c\xd5\x1d\x71\x4d\x10\xaa\xa1\xcd\x0f\x46\x71\xb0\xdf\xa0\x56\x9d\x3c\xcd\x5a\xa8\x07\xbc\xe4\xd1\x7b\x54\x17\xf9\x8a\x75\xca\x77\xcb\x7a\x73\x31\xbb\x74\xb4\xe9\x75\x18\x43\xec\xed\x38\xed\x7d\x42\xc5\x80\x73\xc4\x62\xac\x8c\x94\x78\xea\xc4\x8a\x77\x5b\x3c\xd2\x16\x0d\x2d\xb9\x91\x1a\xd3\x14\x76\xb6\xf7\xd1\x8f\x2e\x7b\xfb\xf9\xaf\x9a\xcd\x19\xd0\xbb\x77\x0f\x4d\x1b\x7d\x79\x2e\xd7\x94\x5d\xfe\x7d\x3d\x0c\x21\xe6\x84\x86\x6a\xb1\xe2\xf2\x46\x8a\x43\x3e\x61\xd8\x55\x09\xa3\xc4\xb3\xb9\xbf\xcb\xdd\x2e\x76\x8a\xe1\x63\xf2\xd1\xa7\x64\x87\x68\x4b\x34\x5b\x92\x30\xa0\x55\x7f\xd4\xbf\x5a\xb2\x2a\x0f\x73\xa4\xec\xf2\x6c\xc1\xbe\x06\x00\x4f\xd1\x09\xa7\xe8\x01\x00\x00")
+
+func migrations_35_add_fantasy_legs_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_35_add_fantasy_legs_up_sql,
+		"migrations/35_add_fantasy_legs.up.sql",
+	)
+}
+
+var _migrations_35_add_fantasy_legs_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x4b\xcc\x2b\x49\x2c\xae\x8c\x2f\x4e\xce\x2f\xca\xcc\x4b\x8f\x2f\x2a\xcd\x49\x2d\xb6\xe6\xe2\x02\x2b\xf6\xf4\x73\x71\x8d\x40\x52\x9c\x93\x9a\x5e\x1c\x5f\x90\x93\x58\x99\x5a\x14\x9f\x99\x62\xcd\xc5\xe5\xe8\x13\xe2\x1a\x04\x35\x13\x24\xa9\x00\xd6\xe6\xec\xef\x13\xea\xeb\x87\xa4\xaf\x20\x27\xb1\x32\xb5\x28\x3e\x33\xc5\x9a\x0b\x30\x00\x0b\xe7\x2a\xb6\x85\x00\x00\x00")
+
+func migrations_35_add_fantasy_legs_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_35_add_fantasy_legs_down_sql,
+		"migrations/35_add_fantasy_legs.down.sql",
+	)
+}
+
+var _seeds_default_3_fantasy_scoring_rules_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x93\xb1\xae\x9b\x30\x14\x86\x77\x3f\xc5\xd9\x48\x24\x40\x69\xaa\x4e\x55\x07\x0a\x26\x45\xa1\xb8\x0a\x26\xea\x66\x59\x70\x12\xac\x52\x9b\x62\x33\xe4\xed\x2b\xd2\xf4\x26\x03\x52\xb8\xba\x77\xb5\xfd\x7d\x3e\xff\x91\xfe\x20\x80\x54\x6a\x27\xed\x05\x7a\xa3\xb4\xb3\xd0\xe3\x00\xa3\x56\x0e\xcc\x09\x50\xd6\x2d\xf4\x9d\xbc\xe0\x00\xd6\x49\xa7\xac\x53\x35\x28\x0d\xdf\xa5\xab\xdb\xf0\xe5\xc8\x86\x24\x08\x60\x8f\x17\x6c\xc0\x68\x38\xfd\x33\x0a\x5b\x9b\x41\xe9\xb3\x18\xc6\x0e\xad\x18\xb5\xfa\x33\xa2\x46\x6b\x85\xd1\xe2\x2c\x7f\xa3\x98\x04\x21\xc9\x8a\x92\x1e\x38\x64\x05\x67\xf3\x28\xac\xa6\xd7\xfe\x75\x04\xff\x36\xa7\x0f\xda\x38\x5c\x93\x63\x94\x57\xb4\x24\xb0\xf2\x62\x56\x15\x9c\x1e\x44\xc9\x0f\xd9\x9e\x8a\x5d\xce\xbe\x46\xb9\x60\x69\x4a\x8b\x32\x3b\x52\xcf\x07\xef\x97\xea\x3a\xeb\xf9\xb0\xf5\xc1\x93\xa3\x33\x67\xd4\x38\x48\x87\x0d\x34\x78\x92\x63\xe7\xbc\xb5\xbf\x50\x25\xad\x55\xd6\x4d\xb2\x0f\x6f\x97\x35\x28\x5d\x3b\xb9\x82\x77\x90\xb5\x28\x1b\xdb\x9a\xeb\x6c\x9b\xf0\xd3\x13\x61\xc2\x78\x24\xb6\x8f\xcb\xf9\xb8\x98\xb8\xef\x60\xbb\x98\x59\x1e\xf5\xce\x74\xd2\xba\x6f\xea\x16\x68\xf3\xec\xaf\x9c\x46\xbb\x8a\x0a\x96\x8a\x9c\xee\x68\x91\x94\xaf\x09\x37\x0b\x2f\xcf\x39\x8b\x2f\x8f\x3c\x8b\xd7\x03\x62\x5f\xd6\x66\xc0\xa7\xf9\x09\x2b\x20\x66\x45\x9a\x67\x31\x7f\x2c\xcd\x1a\x12\x06\xd5\x8f\x24\xe2\x14\x4a\xca\x09\xfc\x2f\xfb\x17\xa0\x3f\xe3\xbc\x4a\x68\x12\xde\x6a\x45\xe0\x5a\xac\xc7\x1b\x6d\x1c\x7e\x26\x7f\x07\x00\x7c\xbb\x99\x6c\x2a\x04\x00\x00")
+
+func seeds_default_3_fantasy_scoring_rules_sql() ([]byte, error) {
+	return bindata_read(
+		_seeds_default_3_fantasy_scoring_rules_sql,
+		"seeds/default/3_fantasy_scoring_rules.sql",
+	)
+}
+
+var _migrations_36_add_pool_series_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x52\xc1\x8e\x9b\x30\x14\xbc\xfb\x2b\xe6\x08\x52\x8e\xbd\xe5\x44\xc3\xcb\xd6\x2a\x98\x94\x18\x35\xe9\xc5\xa2\xf0\x76\x85\xd4\x98\xd6\x86\xad\xf6\xef\x2b\xb2\x2c\x89\x20\xab\x54\xed\xd1\x4f\x6f\xe6\xcd\x78\x66\x93\x53\xa4\x09\x3a\xfa\x98\x10\xe4\x16\x2a\xd3\xa0\x83\xdc\xeb\x3d\x7e\xb6\xed\x0f\xe3\xd9\x35\xec\x11\x08\xa0\xa9\x51\x14\x32\x3e\xaf\xa8\x22\x49\xb0\xcb\x65\x1a\xe5\x47\x7c\xa6\x23\x62\xda\x46\x45\xa2\xd1\xf7\x4d\x6d\x9e\xd8\xb2\x2b\x3b\x36\xcf\x1f\x82\x70\x25\x00\x5b\x9e\x18\x9a\x0e\x7a\x02\x0f\xd3\xa7\x9b\x53\x7d\xdc\xd1\x72\x5a\x39\x2e\x3b\xae\x4d\xd9\x41\xcb\x94\xf6\x3a\x4a\x77\xf8\x2a\xf5\xa7\xf3\x13\xdf\x32\x45\xd3\xfe\x24\xc6\xb6\xbf\x83\x50\x84\x6b\x21\xa2\x44\x53\x3e\xba\x1c\x7c\x79\x01\x00\x51\x1c\x63\x93\x25\x45\xaa\x66\xd6\x5f\x5d\x9b\x37\xc7\x39\x6d\x29\x27\xb5\xa1\xd9\xa7\x34\x75\xb8\xfa\x2b\x22\xcf\xbf\x7a\xb6\x15\x43\x2a\x4d\x0f\x94\xdf\x41\x55\xa5\x73\x2f\xa6\x7d\x66\x87\x98\x36\x32\x8d\x92\xb5\x10\x63\x52\x85\x92\x5f\x0a\x82\x54\x31\x1d\x66\xb0\x41\x9b\x37\xbd\x6d\x86\x63\xec\xbd\x69\xed\x28\x75\x12\x20\x80\x4c\x9d\x4d\x78\x14\x7b\xa9\x1e\xf0\xbd\x73\xcc\xc1\xb8\xd6\xd4\xab\xb9\xe4\xf0\x72\xfa\x56\x49\x5e\xa5\x76\xae\xb4\xfe\x91\xdd\x7f\x17\x65\xd2\x31\x63\xb8\x93\xc0\xa3\x6b\x4f\x66\x70\xb5\x44\x8e\xff\x35\x23\xb8\x40\xbb\xf6\x1d\xe0\xbb\x88\xf2\xd4\xf6\xb6\x7b\x4b\x66\x02\x0c\x64\x55\xef\x1c\xdb\xea\x65\xd9\xdf\xde\xb3\x9b\x6e\x5c\x51\x0f\xf3\x2b\x31\xcd\x89\xff\xa9\xdf\x63\x42\xb7\x5a\x31\x4b\xc8\x5c\x19\xce\xd4\x22\xbf\xab\x52\x20\xb8\xac\x86\x6b\xf1\x67\x00\x7b\xd4\x50\x5c\x2a\x04\x00\x00")
+
+func migrations_36_add_pool_series_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_36_add_pool_series_up_sql,
+		"migrations/36_add_pool_series.up.sql",
+	)
+}
+
+var _migrations_36_add_pool_series_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x8f\x41\x0a\xc2\x40\x0c\x45\xf7\x39\x45\x0e\xe0\x0d\xba\xaa\x76\x84\xc2\xd8\x4a\x3b\x42\x77\xa1\xd4\x08\x03\x32\xa3\x89\x15\xbc\xbd\x74\x6c\xa1\xb8\xd0\x6d\x78\xff\xff\xbc\xa2\xa9\x8f\xe8\xf2\xad\x35\x58\xee\xd1\x74\x65\xeb\x5a\x1c\x7a\x91\x17\x3d\xa4\x0f\x7a\x61\xd1\x0c\x20\x61\x65\x55\x98\x6e\x85\xdd\x62\xbc\x2a\x8d\xc1\xdf\x47\x0e\xac\x4a\x31\x90\xb2\x78\x56\x52\x9e\x6e\x03\x67\x00\xb9\x75\xa6\x99\x27\x52\x02\x10\x11\x53\xe1\xae\xb6\xa7\x43\xb5\x6a\x9c\xd3\xfe\xbc\xf9\x0f\x2d\x13\xbf\xd0\x8f\x48\x7c\xb2\x2c\x0e\xdf\xaa\xd3\x47\xa4\x2c\x9e\x35\x83\xf7\x00\xc9\x10\x17\x13\x0d\x01\x00\x00")
+
+func migrations_36_add_pool_series_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_36_add_pool_series_down_sql,
+		"migrations/36_add_pool_series.down.sql",
+	)
+}
+
+var _migrations_37_add_over_under_cutoffs_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\xcd\x4a\x03\x31\x14\x85\xf7\x79\x8a\xb3\x6c\xa1\x88\x2e\x5c\x55\x85\x6b\x72\xc5\xd0\xcc\x54\xd2\x14\xda\x55\x98\x3a\x99\xb6\x10\x1b\xc9\x64\x0a\xbe\xbd\x68\x05\x45\xba\xe8\xfe\xfc\x7c\x9f\xb4\x4c\x8e\xe1\xe8\xd1\x30\xf4\x13\xea\xb9\x03\xaf\xf4\xc2\x2d\x90\x8e\x21\xfb\xe1\xd0\x86\xec\x5f\x87\x92\xba\xae\xc7\x48\x00\xdb\xe6\x2d\xc0\xf1\xca\x7d\x67\xeb\xa5\x31\x78\xb1\xba\x22\xbb\xc6\x8c\xd7\x13\x01\x74\xcd\x31\xe5\xd0\xfa\xf7\x9c\x36\xcd\x66\x1f\xf7\xe5\x03\x8a\xa5\xae\xc8\xfc\x76\xe4\x33\xcb\x19\x46\xe7\xb2\x0f\xb8\xbe\xba\x05\xd5\xea\xec\xd2\xdd\x3d\x6e\xc6\x5f\x37\x87\x54\x4e\x20\x62\x3c\x15\x82\x8c\x63\xfb\xe3\x11\xc3\xb6\x17\x00\x40\x4a\x41\xce\xcd\xb2\xaa\xff\xb9\x95\x5d\x0e\xfd\x2e\xc5\xd6\xe7\x21\x9e\x56\x26\x97\x36\xfe\xc2\x28\x96\xba\x22\x33\x15\x9f\x03\x00\x32\x4c\xd3\x55\x48\x01\x00\x00")
+
+func migrations_37_add_over_under_cutoffs_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_37_add_over_under_cutoffs_up_sql,
+		"migrations/37_add_over_under_cutoffs.up.sql",
+	)
+}
+
+var _migrations_37_add_over_under_cutoffs_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\x49\x4d\x2f\xe6\x52\x50\x50\x50\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xc9\x28\x4a\x2d\xce\xc8\xcf\x49\x89\x2f\x2a\xcd\x49\xd5\x21\x4a\x65\x41\x51\x7e\x52\x62\x52\x66\x4e\x66\x49\xa5\x35\x17\x17\xd8\x5c\x88\x5d\x08\xc5\xf9\x65\xa9\x45\xf1\xa5\x79\x29\xa9\x45\xf1\xc9\xa5\x25\xf9\x69\x69\xc5\xd6\x5c\x80\x01\x00\xbe\xab\x9f\x51\x96\x00\x00\x00")
+
+func migrations_37_add_over_under_cutoffs_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_37_add_over_under_cutoffs_down_sql,
+		"migrations/37_add_over_under_cutoffs.down.sql",
+	)
+}
+
+var _seeds_default_4_over_under_cutoffs_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\x41\x6b\xab\x40\x14\x46\xf7\xf3\x2b\xbe\x9d\x09\xa8\xef\xf1\xe0\x75\x53\xba\xb0\x3a\x06\x89\x38\x25\x8e\x69\x77\xc3\x44\xaf\x51\x6a\x66\x8a\x8e\x29\xf9\xf7\x25\x29\x2d\x59\x04\xda\xfd\xb9\x87\xfb\x9d\x20\xc0\x73\x6f\xf0\x36\xda\x9d\xde\xf5\x43\xef\x4e\xd0\x0e\x76\x84\xde\xd9\x23\xe1\xbd\xeb\xeb\x0e\x1a\x07\xed\xea\x0e\xb5\x9d\x8d\x9b\xa0\x27\xb4\xfa\x68\x47\x6a\xa0\x4d\x83\xde\x4d\x2c\x08\x60\x8f\x34\xfe\x99\x4d\x43\x23\x06\xda\xc3\xe9\x57\x9a\xe0\x3a\xfa\x66\x5d\x37\xd2\xd4\xd9\xa1\x09\xb1\xa6\x13\x35\xb0\x06\x7b\x7d\xa0\x90\x65\x45\xc9\x37\x12\x59\x21\xc5\x45\xa3\x2e\x1a\x55\xcf\xce\xb6\xed\x84\xc5\x99\xf2\xbf\x3c\xea\xea\x57\x1f\xc6\x3a\x5a\xb2\x6d\x94\x57\xbc\x64\x58\x78\x89\x90\x91\xfa\xe7\xf9\xf8\x1b\xde\xfd\xf7\xe1\xe9\xd9\xd9\x3d\x19\x1a\xb5\xa3\x06\x0d\xb5\x7a\x1e\x9c\xb7\xf4\xcf\x6c\x2c\xaa\x42\xf2\x8d\x2a\xe5\x26\x5b\x73\xb5\xca\xc5\x63\x94\x2b\x91\xa6\xbc\x28\xb3\x2d\xff\x9d\x24\xe7\xd1\xaa\xe2\x4a\xa4\x2a\xe7\x2b\x5e\x24\xe5\x8f\x67\x4c\x14\x88\x45\x91\xe6\x59\x2c\x3f\xb7\x2d\x91\x08\x54\x4f\x49\x24\x39\x4a\x2e\x19\x6e\x6d\xc5\x03\xf8\x4b\x9c\x57\x09\x4f\xc2\x5b\x29\x18\x2e\x31\xae\x31\x63\x1d\xdd\xb3\x8f\x01\x00\x35\x3d\x12\xf7\xe3\x01\x00\x00")
+
+func seeds_default_4_over_under_cutoffs_sql() ([]byte, error) {
+	return bindata_read(
+		_seeds_default_4_over_under_cutoffs_sql,
+		"seeds/default/4_over_under_cutoffs.sql",
+	)
+}
+
+var _migrations_38_add_pool_void_rules_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\x31\x6b\xc3\x30\x10\x46\x77\xfd\x8a\x6f\xb3\x0d\x1e\xb2\x87\x16\x14\xfb\x42\x45\x2e\x4a\xb1\x4e\x34\x99\x84\x43\x44\x29\x18\x2b\xd4\x69\xa0\xff\xbe\x58\x6d\x03\xcd\xd4\xf9\xde\x77\xbc\xd7\x74\xa4\x85\x20\x7a\xc5\x04\xb3\x86\xdd\x09\x68\x6f\x9c\x38\x9c\x53\x1a\xc2\x35\xbd\x9d\xc2\xfb\xc7\x10\x27\x94\x0a\xb8\x7c\x9e\x23\x84\xf6\x92\x41\xeb\x99\xf1\xdc\x99\xad\xee\x0e\xd8\xd0\xa1\x56\xc0\xcc\xde\x11\xcd\x13\x35\x1b\x94\xf9\x62\x2c\xca\x42\x33\x87\x17\x63\x8b\x1a\x85\xf3\x2b\x27\x46\xbc\x50\x51\x55\xf3\xbe\x3f\xf6\xe3\x29\x8d\xa1\x3f\xa6\x6b\x84\xb1\xf2\x3b\xff\x7b\x78\x7c\xc0\x22\xf3\x63\xba\x7c\x1b\xa9\x6a\xa9\x94\x66\xa1\xee\xa7\x66\xf6\x9f\x14\x00\xe8\xb6\x45\xb3\x63\xbf\xb5\x77\x89\xb7\xba\xfc\xa1\xfe\x07\x3c\xc4\xd7\x29\x5b\xdd\xea\x5a\x5a\x6b\xcf\x82\xc5\x52\x7d\x0d\x00\x64\xdf\xf6\x08\x4d\x01\x00\x00")
+
+func migrations_38_add_pool_void_rules_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_38_add_pool_void_rules_up_sql,
+		"migrations/38_add_pool_void_rules.up.sql",
+	)
+}
+
+var _migrations_38_add_pool_void_rules_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xc8\xcf\xcf\x29\xe6\x52\x50\x50\x50\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xcb\xcf\x4c\x89\x2f\x2a\xcd\x49\xd5\x21\xa4\x28\x27\x35\xbd\xd8\x9a\x8b\x0b\x6c\x0c\xc4\x6c\x84\x02\x90\x2d\xf1\x70\xa3\x8a\xad\xb9\x00\x03\x00\x21\xe5\x61\xd2\x83\x00\x00\x00")
+
+func migrations_38_add_pool_void_rules_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_38_add_pool_void_rules_down_sql,
+		"migrations/38_add_pool_void_rules.down.sql",
+	)
+}
+
+var _seeds_default_5_pool_void_rules_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\x41\x6b\xc2\x40\x10\x85\xef\xfb\x2b\xde\x2d\x0a\x1b\x0f\x5e\x4b\x0f\xc1\xac\x18\x08\x9b\x62\x12\x6d\x4f\x61\xed\x4e\x8d\x34\xee\x8a\x3b\x2a\xfe\xfb\x92\x48\xc1\xdc\xda\xe3\x63\xde\xf7\xc1\x9b\x38\xc6\xb6\x35\x8c\x40\xcc\x1d\x1d\xc9\x31\xac\xa7\x80\xdb\x81\x5b\x74\xb4\x0f\xb8\xb5\x3e\x10\x8e\x86\x3f\x5b\xdc\x4c\xc0\xd5\x1f\x2c\x59\x89\x6f\xba\x93\x85\x77\x38\x79\xdf\x89\x38\x06\xdf\x4f\x34\x43\x32\xe4\x07\x7f\xf4\x67\x02\xb7\xc6\xc1\xec\x8c\xb3\xde\x35\x66\xe7\xaf\x34\x28\x1e\xf2\x43\xf8\x3d\x91\x9d\x89\x4c\x97\x6a\x5d\x21\xd3\x55\x31\x58\x9a\xbe\xd8\x9c\x2f\x1d\x05\x4c\x7a\xbd\x44\x1f\xe4\x58\x27\xe1\x3c\xd3\x54\x6c\x92\xbc\x56\xa5\xc0\x24\x5a\xcd\x57\x91\x44\x94\xe4\x79\xb3\xcd\x74\x24\x31\x97\x88\xcc\x85\xfd\x9e\x1c\x9d\x0d\x93\x85\xa5\x2f\x73\xe9\x38\x9a\xca\x1e\x28\x36\x6a\xdd\xd4\x3a\x55\xeb\xff\x71\xcb\x44\x57\x49\xf9\xf1\x67\x48\x14\x1a\x8b\x42\x2f\xf3\x6c\x51\x3d\x16\x4d\x91\x16\xa8\xdf\xd2\xa4\x52\x28\x55\x25\x30\x2c\xc4\x2b\xd4\xfb\x22\xaf\x53\x95\xce\xfa\x2c\x05\xc6\x9b\x9f\x0b\xe3\x67\x08\x0c\xef\x78\x2e\x38\xcf\xf4\x22\x7e\x06\x00\xb9\x4a\x93\x93\xea\x01\x00\x00")
+
+func seeds_default_5_pool_void_rules_sql() ([]byte, error) {
+	return bindata_read(
+		_seeds_default_5_pool_void_rules_sql,
+		"seeds/default/5_pool_void_rules.sql",
+	)
+}
+
+var _migrations_39_add_reserve_legs_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x92\x4d\x6f\x9b\x40\x10\x86\xef\xfc\x8a\xf7\x08\x52\x0e\x3d\xf4\x16\xa9\xd2\x16\xc6\xf5\x2a\x78\x9d\xb2\xbb\x8a\xd3\xcb\x8a\x84\x55\xba\x92\x6d\x10\xbb\x24\xea\xbf\xaf\xf8\x30\x89\x8d\xdb\x1e\x7a\x04\x86\x67\xde\x79\x66\x58\xae\xa8\x80\x62\x5f\x73\xc2\xde\xbe\xf8\x08\x00\x58\x96\x21\xdd\xe6\x7a\x23\xc0\x57\x10\x5b\x05\xda\x71\xa9\x24\x5a\xeb\x6d\xfb\x6a\x4d\x53\x7b\x17\x5c\x7d\x04\x17\x0a\xe9\x9a\xd2\x3b\xc4\x8b\x6f\x5f\xf0\x29\xb9\xf9\x17\xaf\xd9\x97\xcf\xb6\x32\x4f\xbf\xa0\x35\xcf\x50\xd0\x8a\x0a\x12\x29\xc9\x21\x0d\x62\x57\x25\xd8\x0a\x64\x94\x93\x22\x48\x52\x10\x3a\xcf\x6f\xa3\x28\x2d\x88\x29\x82\x16\xfc\xbb\x26\x70\x91\xd1\xee\x02\xde\x03\x4c\x53\xd7\x7b\xe3\x2a\x73\x99\x2e\x42\x8f\xed\x4b\xa0\x25\x17\xdf\xf0\x14\x5a\x6b\x11\x4f\xf5\x37\x8b\x51\x13\x3c\xac\xa9\xa0\x2b\x0a\xe4\x30\xd1\x79\xac\x51\xe8\x22\x8f\xf1\x6f\x65\xe3\x11\x0f\x56\x5c\x35\xce\x7c\xfa\x1b\xf7\x05\xdf\xb0\xe2\x11\x77\xf4\x88\x8c\x56\x4c\xe7\x0a\x5d\xe7\x2a\xf3\x62\x8f\xb6\x2d\x83\x35\xaf\x9f\xe3\x49\xe9\x94\xf3\x82\xf0\x41\x5f\x5f\xb0\xf0\x97\x32\x99\xb2\x8c\x46\x44\x9f\xe7\x6f\x84\x6b\x0b\x38\x03\x9c\x54\xfc\x37\xe8\x50\x86\xe7\x9f\xc6\x87\x32\x74\x1e\x8a\x76\x6a\xc6\x8c\x49\x3b\x6f\xdb\xb9\xc3\x07\x70\xff\x7e\x9c\x71\xac\x0b\xee\x60\xa1\xf8\x86\xa4\x62\x9b\x7b\x3c\x70\xb5\x1e\x1e\xf1\x63\x2b\xe8\x3d\xda\xc9\xed\xb1\x7e\x8b\x93\x28\x79\xdf\xda\x1f\xae\x68\xdc\xda\x7c\x4a\x7d\x97\xf9\x7c\xa6\x8d\x5e\xbf\xa1\xe0\x0e\x36\xb9\x8d\x7e\x0f\x00\x1d\x5b\x99\xcf\x64\x03\x00\x00")
+
+func migrations_39_add_reserve_legs_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_39_add_reserve_legs_up_sql,
+		"migrations/39_add_reserve_legs.up.sql",
+	)
+}
+
+var _migrations_39_add_reserve_legs_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x49\x4d\x8f\x2f\x2e\x4f\x2c\x28\xb6\xe6\xe2\x02\x2b\xf0\xf4\x73\x71\x8d\x40\x55\x50\x1c\x5f\x90\x9f\x9f\x13\x9f\x99\x12\x5f\x94\x5a\x9c\x5a\x54\x96\x1a\x5f\x90\x5f\x9c\x59\x92\x99\x9f\x67\xcd\xc5\xe5\xe8\x13\xe2\x1a\x04\x35\x36\x27\x35\xbd\x98\x4b\x41\x41\x41\x01\x6c\x92\xb3\xbf\x4f\xa8\xaf\x1f\x92\x51\x45\xa9\x05\x39\x89\xc9\xa9\x29\xf1\x49\x95\x3a\x78\x95\xa1\x5b\x02\x18\x00\x32\x97\xe1\x1e\xba\x00\x00\x00")
+
+func migrations_39_add_reserve_legs_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_39_add_reserve_legs_down_sql,
+		"migrations/39_add_reserve_legs.down.sql",
+	)
+}
+
+var _migrations_40_add_currency_rates_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x90\x41\x6b\x83\x30\x1c\xc5\xef\xf9\x14\xef\xa6\x82\x87\xdd\xbb\x09\x99\xfe\xc5\xd0\x18\x45\x23\x6d\x77\x11\x51\x07\x83\x55\x8b\xb5\x8c\x7d\xfb\x61\x96\x31\xa5\xb0\x5d\x04\xf3\x7e\xef\xe5\xbd\x84\x05\x71\x4d\xd0\xfc\x59\x12\x44\x0c\x95\x69\xd0\x51\x94\xba\x44\x7b\x9b\xa6\x7e\x68\xdf\xfa\x2b\x5c\x06\xb4\x63\xd7\x43\xd3\x51\x1b\x46\x55\x52\x22\x2f\x44\xca\x8b\x13\xf6\x74\xf2\x19\x70\x79\x6f\xda\xfe\x0a\xa1\x56\x48\x98\x50\xb8\x87\x6b\xa5\xe0\x09\x0f\xde\x82\x9e\xef\xc3\x2c\x69\x14\xa1\xe0\x3a\x51\x76\x50\x8e\x0f\xa7\xca\x97\x6f\xc2\x65\x5c\x57\xb9\xe3\x19\xff\x30\xce\xdf\x7e\xe6\xed\x18\xfb\x7f\xc4\x67\x3d\x35\xb3\x1d\xf2\x3a\x8d\xe7\xfa\xe7\x7c\x5b\x62\x89\x9e\xc7\x3f\xc4\x25\x05\x11\x85\x22\xe5\xf2\xae\xba\x11\x03\xbb\xf0\x76\xe9\x9a\xb9\xef\xea\x66\x86\x16\x29\x95\x9a\xa7\x39\x0e\x42\x27\xe6\x17\x2f\x99\xa2\xdf\x80\x88\x62\x5e\x49\x8d\x61\xfc\x70\x8d\x7b\xf5\xb2\x70\x37\x85\xfd\x75\x41\xc3\xda\xdb\xb7\xb3\x1e\x83\x0d\xc7\xbc\x1d\xfb\x1a\x00\x3c\x5e\x24\x41\xeb\x01\x00\x00")
+
+func migrations_40_add_currency_rates_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_40_add_currency_rates_up_sql,
+		"migrations/40_add_currency_rates.up.sql",
+	)
+}
+
+var _migrations_40_add_currency_rates_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x46\x00\xb9\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x75\x72\x72\x65\x6e\x63\x79\x5f\x72\x61\x74\x65\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x75\x72\x72\x65\x6e\x63\x69\x65\x73\x3b\x0a\x03\x00\x00\xa5\x0e\x95\x46\x00\x00\x00")
+
+func migrations_40_add_currency_rates_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_40_add_currency_rates_down_sql,
+		"migrations/40_add_currency_rates.down.sql",
+	)
+}
+
+var _seeds_default_6_currencies_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\x3f\x6f\xc2\x30\x14\xc4\x77\x7f\x8a\xdb\x02\x92\xc3\xd0\xa5\x03\xea\x40\xb1\x69\x91\xa2\x04\x91\xb8\xed\x56\x99\xe4\x01\x91\x12\x1b\x39\x36\x15\xdf\xbe\x4a\xf8\x53\xb6\xb6\xe3\xb3\xef\xf7\xee\x7c\x8e\x63\x08\x2a\xeb\x56\x37\x38\x34\xba\xa4\x0e\xda\x54\x70\x36\x98\xaa\x36\x3b\xd8\x2d\x74\x6b\x83\xf1\x1d\x0e\xe4\x50\x06\xe7\xc8\x94\xa7\x09\x94\xa9\x3d\x8e\xba\x09\xd4\xa1\x0d\x9d\x67\x71\x8c\x0d\x9d\x41\xaa\xe0\xf7\x75\x87\x2f\x7d\x9a\xa2\xb4\xe6\x48\xce\x53\x75\x5b\xa4\xdd\x8f\x6e\x73\x82\xdf\x13\xbc\x76\x3b\x1a\x76\xdc\x1c\xd8\x32\xcd\xe5\xba\xc0\x32\x2d\xb2\xab\x6f\x4d\x1d\x46\xa5\xad\x88\x5f\xc2\x72\xb4\xc3\x64\xac\xa7\x31\x7b\x9b\x25\x4a\xe6\x0c\xa3\x28\x2f\xd6\x11\xc7\x23\x47\x24\xb2\xf7\x34\xe2\x88\x74\xf0\x76\x47\x86\x9c\xee\xa3\x54\xb4\xd5\xa1\xf1\xd1\x98\xf7\x6a\xa9\x7a\xf5\x03\x47\xf4\x3a\x4b\x16\x9f\x6a\xf5\x1b\xa0\x72\xf1\x3f\xe0\xe5\x79\xf5\x67\x80\x65\x29\xe6\x59\xba\x48\x96\xf3\xe2\xfc\xda\x31\x44\x06\xb5\x12\xb3\x42\x22\x97\x05\xc3\xf5\xab\x9e\x20\x3f\xe6\x89\x12\x52\x4c\x2e\x7d\x30\x0c\x8d\xdc\xdf\xf4\x33\x67\x18\x3a\xba\x3f\x37\xd6\xd3\x94\x7d\x0f\x00\xc9\x92\x3b\x1e\x00\x02\x00\x00")
+
+func seeds_default_6_currencies_sql() ([]byte, error) {
+	return bindata_read(
+		_seeds_default_6_currencies_sql,
+		"seeds/default/6_currencies.sql",
+	)
+}
+
+var _migrations_41_add_pool_templates_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\x31\x4f\xc3\x30\x14\x84\xf7\xfc\x8a\x37\xb6\x12\x03\x03\x1b\x93\xdb\xba\xc2\x90\xa4\xa5\xb1\x45\xcb\x62\x59\xe9\x53\x65\xe1\xd8\x91\xf3\x5c\xe8\xbf\x47\x89\x44\xa9\x00\x29\x74\x7c\xbe\xef\xce\xa7\x9b\x6f\x38\x93\x1c\x24\x9b\xe5\x1c\xc4\x12\xca\x95\x04\xbe\x15\x95\xac\xa0\x0d\xc1\x69\xc2\xa6\x75\x86\xb0\x83\x49\x06\x60\xf7\xa0\x94\x58\x0c\x54\xa9\xf2\x1c\xd6\x1b\x51\xb0\xcd\x0e\x9e\xf8\x0e\x16\x7c\xc9\x54\x2e\x21\x25\xbb\xd7\x07\xf4\x18\x0d\xa1\x3e\xde\x4d\xa6\x37\x19\x80\x37\x0d\x82\xe4\x5b\xf9\x6d\x56\xa5\x78\x56\xbc\x17\xe9\xd4\xfe\x10\xfb\xd7\xc3\x97\xa5\x3f\xea\x14\x23\xfa\xfa\xf4\x07\x96\x4c\x34\x9e\x10\x61\xc1\xe7\xa2\x60\xf9\x59\x3d\x57\xba\xed\x39\xe3\x5c\xa8\x0d\xd9\xe0\x47\xc0\xe4\x2d\xe9\xa3\x71\x69\x2c\xb1\xb1\x5e\x0f\x70\x8b\x51\x3b\xeb\x47\x79\xf3\x71\x1d\x7f\x99\x4f\xb6\x7e\x43\xba\xe6\x87\x7f\x39\xea\xe0\xbb\xe0\x86\x59\x3a\x78\xac\x56\xe5\xac\x6f\xea\x03\x5d\x4c\x1f\xd1\x10\xee\xb5\x21\x90\xa2\xe0\x95\x64\xc5\x1a\x5e\x84\x7c\x18\x4e\x78\x5d\x95\xfc\x77\xb8\x0f\xef\x93\x69\x36\xbd\xcf\x3e\x07\x00\xf0\x77\x69\xf9\x62\x02\x00\x00")
+
+func migrations_41_add_pool_templates_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_41_add_pool_templates_up_sql,
+		"migrations/41_add_pool_templates.up.sql",
+	)
+}
+
+var _migrations_41_add_pool_templates_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x25\x00\xda\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x6f\x6f\x6c\x5f\x74\x65\x6d\x70\x6c\x61\x74\x65\x73\x3b\x0a\x03\x00\x5d\xa4\x83\x96\x25\x00\x00\x00")
+
+func migrations_41_add_pool_templates_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_41_add_pool_templates_down_sql,
+		"migrations/41_add_pool_templates.down.sql",
+	)
+}
+
+var _migrations_42_recreate_competitors_up_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\x4f\x6f\x9b\x40\x10\xc5\xef\x7c\x8a\x27\x4e\x20\x01\x4a\x95\xde\xa2\x1c\xa8\xbd\x6e\x50\x09\x54\x40\xd4\xe6\x84\xd6\xec\x94\xac\xca\x1f\x97\x5d\xdc\xf4\xdb\x57\x6b\x5c\xbc\x56\xff\x48\x3d\xee\xcc\xbc\x37\xbf\x79\x1b\x86\xb8\xbd\xa9\x9b\xb1\x3f\x90\x96\x7a\x9c\x54\x3d\x1f\x22\xf5\xad\xc3\x77\xae\x30\xd0\x91\x26\x4c\xf3\x80\xfd\x0f\xb4\x63\xc7\x87\x36\xec\x65\x3b\x71\x4d\x01\xd4\x08\xc1\x35\xdf\x73\x45\xca\x09\x43\x9c\x1b\x02\x07\xae\x34\x8e\x34\x29\x39\x0e\xb8\xbd\x41\xc7\x9b\xaf\xd0\x2f\xa4\x08\x9a\xef\x3b\x52\x91\xb3\x29\x58\x5c\x31\x54\xf1\xbb\x94\x21\xd9\x21\xcb\x2b\xb0\xcf\x49\x59\x95\x70\x0f\xf3\xbe\x93\x8d\x1b\xb9\x16\x94\x0b\xcf\x01\x00\x57\x0a\x17\xf3\x2c\xc5\x49\x91\x3d\xa5\x29\xb6\x6c\x17\x3f\xa5\xd5\xa9\x5a\xb7\x34\x90\xa1\xab\x8f\x6f\x3d\x3f\x58\x24\xf4\xaa\x69\x1a\x78\x57\x1b\xad\xa6\x57\x7d\xae\x0f\xbc\xa7\xab\x42\x37\xb6\xa3\x5d\xf8\x58\x24\x8f\x71\xf1\x8c\x0f\xec\x19\x9e\xd9\xec\x3b\xfe\xdd\xff\xa2\xd7\x3d\xd7\xcd\xcb\xca\x7f\x7a\xd5\xeb\x15\x13\x7d\xa1\x89\x86\x86\x14\x4e\x1d\x52\x9e\x14\xfe\x7a\xdc\x19\xcc\x72\xfb\x93\xf2\xd2\xbe\x56\x1b\xda\x24\x2b\x59\x51\x21\xc9\xaa\xdc\x9e\x83\x27\x45\x00\x2b\x99\x00\x26\x8e\x00\x26\x03\xdf\x29\x59\xca\x36\x15\xfe\x31\x83\x5d\x91\x3f\x42\x13\xef\x95\x93\x67\xd8\xe4\xd9\x2e\x4d\x36\x95\xf1\xf5\xb1\xcd\xcd\x05\x0f\x49\xf6\xfe\x6f\x00\x4b\x2a\xf0\x7e\xc5\x11\xd8\x3d\x29\x56\x02\x1d\x5d\x26\x74\x24\x85\xb5\x16\xda\xf9\xf4\xc0\x0a\x66\xcd\x20\x29\xd7\xe3\x11\x67\x5b\xfb\x6f\x96\xfc\xcf\xb6\x6f\x16\x9f\xdf\x78\x9a\x1e\x8b\x67\xd3\x5f\x4c\xef\xed\x0d\xc6\xb5\xe9\xa3\x2b\x5a\xdc\x43\x47\x52\x38\xfe\x9d\xf3\x73\x00\x02\xf7\xa9\x21\x4e\x03\x00\x00")
+
+func migrations_42_recreate_competitors_up_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_42_recreate_competitors_up_sql,
+		"migrations/42_recreate_competitors.up.sql",
+	)
+}
+
+var _migrations_42_recreate_competitors_down_sql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5f\x00\xa0\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x22\x70\x75\x62\x6c\x69\x63\x22\x2e\x22\x63\x6f\x6d\x70\x65\x74\x69\x74\x6f\x72\x5f\x6d\x61\x74\x63\x68\x22\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x22\x70\x75\x62\x6c\x69\x63\x22\x2e\x22\x63\x6f\x6d\x70\x65\x74\x69\x74\x6f\x72\x73\x22\x3b\x0a\x03\x00\x8a\x30\x4a\x5e\x5f\x00\x00\x00")
+
+func migrations_42_recreate_competitors_down_sql() ([]byte, error) {
+	return bindata_read(
+		_migrations_42_recreate_competitors_down_sql,
+		"migrations/42_recreate_competitors.down.sql",
+	)
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -602,8 +854,30 @@ var _bindata = map[string]func() ([]byte, error){
 	"migrations/2_initialize_schema.up.sql": migrations_2_initialize_schema_up_sql,
 	"migrations/30_competitors.down.sql": migrations_30_competitors_down_sql,
 	"migrations/30_competitors_up.sql": migrations_30_competitors_up_sql,
+	"migrations/32_add_pool_status_transitions.down.sql": migrations_32_add_pool_status_transitions_down_sql,
+	"migrations/32_add_pool_status_transitions.up.sql": migrations_32_add_pool_status_transitions_up_sql,
+	"migrations/33_add_leg_results.down.sql": migrations_33_add_leg_results_down_sql,
+	"migrations/33_add_leg_results.up.sql": migrations_33_add_leg_results_up_sql,
+	"migrations/34_add_over_under_to_leg_results.down.sql": migrations_34_add_over_under_to_leg_results_down_sql,
+	"migrations/34_add_over_under_to_leg_results.up.sql": migrations_34_add_over_under_to_leg_results_up_sql,
+	"migrations/35_add_fantasy_legs.down.sql": migrations_35_add_fantasy_legs_down_sql,
+	"migrations/35_add_fantasy_legs.up.sql": migrations_35_add_fantasy_legs_up_sql,
+	"migrations/36_add_pool_series.down.sql": migrations_36_add_pool_series_down_sql,
+	"migrations/36_add_pool_series.up.sql": migrations_36_add_pool_series_up_sql,
+	"migrations/37_add_over_under_cutoffs.down.sql": migrations_37_add_over_under_cutoffs_down_sql,
+	"migrations/37_add_over_under_cutoffs.up.sql": migrations_37_add_over_under_cutoffs_up_sql,
+	"migrations/38_add_pool_void_rules.down.sql": migrations_38_add_pool_void_rules_down_sql,
+	"migrations/38_add_pool_void_rules.up.sql": migrations_38_add_pool_void_rules_up_sql,
+	"migrations/39_add_reserve_legs.down.sql": migrations_39_add_reserve_legs_down_sql,
+	"migrations/39_add_reserve_legs.up.sql": migrations_39_add_reserve_legs_up_sql,
 	"migrations/3_add_foreign_key_indicies.down.sql": migrations_3_add_foreign_key_indicies_down_sql,
 	"migrations/3_add_foreign_key_indicies.up.sql": migrations_3_add_foreign_key_indicies_up_sql,
+	"migrations/40_add_currency_rates.down.sql": migrations_40_add_currency_rates_down_sql,
+	"migrations/40_add_currency_rates.up.sql": migrations_40_add_currency_rates_up_sql,
+	"migrations/41_add_pool_templates.down.sql": migrations_41_add_pool_templates_down_sql,
+	"migrations/41_add_pool_templates.up.sql": migrations_41_add_pool_templates_up_sql,
+	"migrations/42_recreate_competitors.down.sql": migrations_42_recreate_competitors_down_sql,
+	"migrations/42_recreate_competitors.up.sql": migrations_42_recreate_competitors_up_sql,
 	"migrations/4_add_user_roles.down.sql": migrations_4_add_user_roles_down_sql,
 	"migrations/4_add_user_roles.up.sql": migrations_4_add_user_roles_up_sql,
 	"migrations/5_add_email_unique_constaint_on_user.down.sql": migrations_5_add_email_unique_constaint_on_user_down_sql,
@@ -617,6 +891,12 @@ var _bindata = map[string]func() ([]byte, error){
 	"migrations/9_remove_pool_null_constraints.down.sql": migrations_9_remove_pool_null_constraints_down_sql,
 	"migrations/9_remove_pool_null_constraints.up.sql": migrations_9_remove_pool_null_constraints_up_sql,
 	"migrations/migration-data.go": migrations_migration_data_go,
+	"seeds/default/1_pool_defaults.sql": seeds_default_1_pool_defaults_sql,
+	"seeds/default/2_over_under_defaults.sql": seeds_default_2_over_under_defaults_sql,
+	"seeds/default/3_fantasy_scoring_rules.sql": seeds_default_3_fantasy_scoring_rules_sql,
+	"seeds/default/4_over_under_cutoffs.sql": seeds_default_4_over_under_cutoffs_sql,
+	"seeds/default/5_pool_void_rules.sql": seeds_default_5_pool_void_rules_sql,
+	"seeds/default/6_currencies.sql": seeds_default_6_currencies_sql,
 }
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
@@ -742,10 +1022,54 @@ var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
 	}},
 	"migrations/30_competitors_up.sql": &_bintree_t{migrations_30_competitors_up_sql, map[string]*_bintree_t{
 	}},
+	"migrations/32_add_pool_status_transitions.down.sql": &_bintree_t{migrations_32_add_pool_status_transitions_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/32_add_pool_status_transitions.up.sql": &_bintree_t{migrations_32_add_pool_status_transitions_up_sql, map[string]*_bintree_t{
+	}},
+	"migrations/33_add_leg_results.down.sql": &_bintree_t{migrations_33_add_leg_results_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/33_add_leg_results.up.sql": &_bintree_t{migrations_33_add_leg_results_up_sql, map[string]*_bintree_t{
+	}},
+	"migrations/34_add_over_under_to_leg_results.down.sql": &_bintree_t{migrations_34_add_over_under_to_leg_results_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/34_add_over_under_to_leg_results.up.sql": &_bintree_t{migrations_34_add_over_under_to_leg_results_up_sql, map[string]*_bintree_t{
+	}},
+	"migrations/35_add_fantasy_legs.down.sql": &_bintree_t{migrations_35_add_fantasy_legs_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/35_add_fantasy_legs.up.sql": &_bintree_t{migrations_35_add_fantasy_legs_up_sql, map[string]*_bintree_t{
+	}},
+	"migrations/36_add_pool_series.down.sql": &_bintree_t{migrations_36_add_pool_series_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/36_add_pool_series.up.sql": &_bintree_t{migrations_36_add_pool_series_up_sql, map[string]*_bintree_t{
+	}},
+	"migrations/37_add_over_under_cutoffs.down.sql": &_bintree_t{migrations_37_add_over_under_cutoffs_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/37_add_over_under_cutoffs.up.sql": &_bintree_t{migrations_37_add_over_under_cutoffs_up_sql, map[string]*_bintree_t{
+	}},
+	"migrations/38_add_pool_void_rules.down.sql": &_bintree_t{migrations_38_add_pool_void_rules_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/38_add_pool_void_rules.up.sql": &_bintree_t{migrations_38_add_pool_void_rules_up_sql, map[string]*_bintree_t{
+	}},
+	"migrations/39_add_reserve_legs.down.sql": &_bintree_t{migrations_39_add_reserve_legs_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/39_add_reserve_legs.up.sql": &_bintree_t{migrations_39_add_reserve_legs_up_sql, map[string]*_bintree_t{
+	}},
 	"migrations/3_add_foreign_key_indicies.down.sql": &_bintree_t{migrations_3_add_foreign_key_indicies_down_sql, map[string]*_bintree_t{
 	}},
 	"migrations/3_add_foreign_key_indicies.up.sql": &_bintree_t{migrations_3_add_foreign_key_indicies_up_sql, map[string]*_bintree_t{
 	}},
+	"migrations/40_add_currency_rates.down.sql": &_bintree_t{migrations_40_add_currency_rates_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/40_add_currency_rates.up.sql": &_bintree_t{migrations_40_add_currency_rates_up_sql, map[string]*_bintree_t{
+	}},
+	"migrations/41_add_pool_templates.down.sql": &_bintree_t{migrations_41_add_pool_templates_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/41_add_pool_templates.up.sql": &_bintree_t{migrations_41_add_pool_templates_up_sql, map[string]*_bintree_t{
+	}},
+	"migrations/42_recreate_competitors.down.sql": &_bintree_t{migrations_42_recreate_competitors_down_sql, map[string]*_bintree_t{
+	}},
+	"migrations/42_recreate_competitors.up.sql": &_bintree_t{migrations_42_recreate_competitors_up_sql, map[string]*_bintree_t{
+	}},
 	"migrations/4_add_user_roles.down.sql": &_bintree_t{migrations_4_add_user_roles_down_sql, map[string]*_bintree_t{
 	}},
 	"migrations/4_add_user_roles.up.sql": &_bintree_t{migrations_4_add_user_roles_up_sql, map[string]*_bintree_t{
@@ -772,4 +1096,16 @@ var _bintree = &_bintree_t{nil, map[string]*_bintree_t{
 	}},
 	"migrations/migration-data.go": &_bintree_t{migrations_migration_data_go, map[string]*_bintree_t{
 	}},
+	"seeds/default/1_pool_defaults.sql": &_bintree_t{seeds_default_1_pool_defaults_sql, map[string]*_bintree_t{
+	}},
+	"seeds/default/2_over_under_defaults.sql": &_bintree_t{seeds_default_2_over_under_defaults_sql, map[string]*_bintree_t{
+	}},
+	"seeds/default/3_fantasy_scoring_rules.sql": &_bintree_t{seeds_default_3_fantasy_scoring_rules_sql, map[string]*_bintree_t{
+	}},
+	"seeds/default/4_over_under_cutoffs.sql": &_bintree_t{seeds_default_4_over_under_cutoffs_sql, map[string]*_bintree_t{
+	}},
+	"seeds/default/5_pool_void_rules.sql": &_bintree_t{seeds_default_5_pool_void_rules_sql, map[string]*_bintree_t{
+	}},
+	"seeds/default/6_currencies.sql": &_bintree_t{seeds_default_6_currencies_sql, map[string]*_bintree_t{
+	}},
 }}
@@ -0,0 +1,161 @@
+package pools
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Audit edit actions of the approval workflow, next to the CREATE, UPDATE
+// and DELETE actions of ordinary edits.
+const (
+	auditRequestApproval = "REQUEST_APPROVAL"
+	auditApprove         = "APPROVE"
+	auditReject          = "REJECT"
+)
+
+// auditTargetPool is the audits.target_type of pool entries.
+const auditTargetPool = "pool"
+
+// ApprovalConfig lists the AccessRoles allowed to take each approval step.
+// Parse it with github.com/caarlos0/env.
+type ApprovalConfig struct {
+	RequesterRoles []string `env:"POOL_APPROVAL_REQUESTER_ROLES" envDefault:"SUPER_ADMIN,ADMIN" envSeparator:","`
+	ApproverRoles  []string `env:"POOL_APPROVER_ROLES" envDefault:"SUPER_ADMIN,ADMIN" envSeparator:","`
+}
+
+// Approvals runs the four-eyes approval workflow: a pool is approved by a
+// different user than the one who created or last edited it.
+type Approvals struct {
+	config     ApprovalConfig
+	validation ValidationConfig
+}
+
+// NewApprovals returns the workflow restricted to the roles in config.
+// Pools are validated with validation before they are approved.
+func NewApprovals(config ApprovalConfig, validation ValidationConfig) *Approvals {
+	return &Approvals{config: config, validation: validation}
+}
+
+// writeAudit adds an audits row for pool id. An empty userID records a
+// system action, stored with a NULL user_id.
+func writeAudit(q Querier, id, userID, action string, content interface{}) error {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return errors.Wrap(err, "encoding audit content")
+	}
+	_, err = q.Exec(`
+		INSERT INTO audits (time, target_id, target_type, user_id, content, edit_action)
+		VALUES (now(), $1, $2, $3, $4, $5)`,
+		id, auditTargetPool, nullString(userID), string(data), action)
+	return errors.Wrapf(err, "auditing %s of pool %s", action, id)
+}
+
+// checkRole fails unless user userID has one of roles.
+func checkRole(q Querier, poolID, userID string, roles []string) error {
+	var role sql.NullString
+	err := q.QueryRow(`SELECT access_role FROM users WHERE id = $1`, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return errors.Errorf("user %s not found", userID)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "reading role of user %s", userID)
+	}
+	for _, allowed := range roles {
+		if role.String == allowed {
+			return nil
+		}
+	}
+	e := newError(CodeForbiddenRole, poolID, "role %s may not do this, need one of %v", role.String, roles)
+	e.Details = map[string]interface{}{"role": role.String, "allowed": roles}
+	return e
+}
+
+// editors returns the users who created and last edited pool id according
+// to the audit log. Either is empty when unknown, e.g. for autogenerated
+// pools.
+func editors(q Querier, id string) (creator, editor string, err error) {
+	err = q.QueryRow(`
+		SELECT
+		  coalesce((SELECT user_id::text FROM audits
+		            WHERE target_id = $1 AND edit_action = 'CREATE'
+		            ORDER BY time LIMIT 1), ''),
+		  coalesce((SELECT user_id::text FROM audits
+		            WHERE target_id = $1 AND edit_action IN ('CREATE', 'UPDATE')
+		            ORDER BY time DESC LIMIT 1), '')`, id).Scan(&creator, &editor)
+	return creator, editor, errors.Wrapf(err, "reading editors of pool %s", id)
+}
+
+type approvalAudit struct {
+	From   Status `json:"from"`
+	To     Status `json:"to"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// step moves pool id from status from to status to on behalf of userID and
+// audits it as action.
+func (a *Approvals) step(q Querier, id, userID string, from, to Status, action, reason string) (*StatusTransition, error) {
+	current, err := lockStatus(q, id)
+	if err != nil {
+		return nil, err
+	}
+	if current != from {
+		return nil, &TransitionError{Code: CodeIllegalTransition, PoolID: id, From: current, To: to}
+	}
+	t, err := transitionStatus(q, id, to, userID, reason)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeAudit(q, id, userID, action, approvalAudit{From: from, To: to, Reason: reason}); err != nil {
+		return nil, err
+	}
+	log.WithFields(log.Fields{"pool": id, "user": userID, "action": action}).Info("pool approval step")
+	return t, nil
+}
+
+// RequestApproval submits a NOT_READY pool for approval.
+func (a *Approvals) RequestApproval(q Querier, id, userID string) (*StatusTransition, error) {
+	if err := checkRole(q, id, userID, a.config.RequesterRoles); err != nil {
+		return nil, err
+	}
+	return a.step(q, id, userID, StatusNotReady, StatusNeedsApproval, auditRequestApproval, "approval requested")
+}
+
+// Approve approves a pool awaiting approval. The approver must differ from
+// the pool's creator and from its last editor, and ValidatePool must find
+// no errors.
+func (a *Approvals) Approve(q Querier, id, userID string) (*StatusTransition, error) {
+	if err := checkRole(q, id, userID, a.config.ApproverRoles); err != nil {
+		return nil, err
+	}
+	creator, editor, err := editors(q, id)
+	if err != nil {
+		return nil, err
+	}
+	if userID == creator || userID == editor {
+		return nil, newError(CodeFourEyes, id, "pool must be approved by someone other than its creator or last editor")
+	}
+	report, err := ValidatePool(q, id, a.validation)
+	if err != nil {
+		return nil, err
+	}
+	if !report.Valid() {
+		e := newError(CodeValidationFailed, id, "pool has %d validation errors", len(report.Errors))
+		e.Details = map[string]interface{}{"errors": report.Errors}
+		return nil, e
+	}
+	return a.step(q, id, userID, StatusNeedsApproval, StatusApproved, auditApprove, "approved")
+}
+
+// Reject sends a pool awaiting approval back to NOT_READY with reason.
+func (a *Approvals) Reject(q Querier, id, userID, reason string) (*StatusTransition, error) {
+	if reason == "" {
+		return nil, newError(CodeReasonRequired, id, "a rejection needs a reason")
+	}
+	if err := checkRole(q, id, userID, a.config.ApproverRoles); err != nil {
+		return nil, err
+	}
+	return a.step(q, id, userID, StatusNeedsApproval, StatusNotReady, auditReject, reason)
+}
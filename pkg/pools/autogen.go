@@ -0,0 +1,347 @@
+package pools
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// autogenLockKey serialises generation runs across replicas.
+const autogenLockKey = 4107365123
+
+// AutogenConfig controls the pool generator. Parse it with
+// github.com/caarlos0/env.
+type AutogenConfig struct {
+	Interval time.Duration `env:"POOL_AUTOGEN_INTERVAL" envDefault:"5m"`
+	// Matches starting between now+Lead and now+Window are eligible.
+	Lead   time.Duration `env:"POOL_AUTOGEN_LEAD" envDefault:"2h"`
+	Window time.Duration `env:"POOL_AUTOGEN_WINDOW" envDefault:"48h"`
+}
+
+// poolDefault is a pool_defaults row.
+type poolDefault struct {
+	LegCount         int
+	Game             string
+	Type             Type
+	Guarantee        decimal.Decimal
+	CarryIn          decimal.Decimal
+	Allocation       decimal.Decimal
+	UnitValue        decimal.Decimal
+	MinUnitPerLine   decimal.Decimal
+	MaxUnitPerLine   decimal.Decimal
+	MinUnitPerTicket decimal.Decimal
+	MaxUnitPerTicket decimal.Decimal
+	Currency         Currency
+}
+
+// candidateMatch is an active upcoming match without a pool of some type.
+type candidateMatch struct {
+	ID            string
+	Game          string
+	Format        string
+	StartTime     time.Time
+	Probabilities []TeamWinProbability
+}
+
+// Generator assembles pools from PoolDefault rows and upcoming matches.
+type Generator struct {
+	store  *Store
+	config AutogenConfig
+	now    func() time.Time
+}
+
+// NewGenerator returns a Generator writing through store.
+func NewGenerator(store *Store, config AutogenConfig) *Generator {
+	return &Generator{store: store, config: config, now: time.Now}
+}
+
+// Run generates pools every config.Interval until ctx is done.
+func (g *Generator) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.config.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := g.Generate(); err != nil {
+			log.WithError(err).Error("pool generation failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Generate creates pools for the eligible matches of every game with pool
+// defaults and returns their ids. Pools are autogenerated, inactive and
+// left in NEEDS_APPROVAL. Matches are used at most once per pool type.
+// FANTASY pools need player selection and are not generated.
+func (g *Generator) Generate() ([]string, error) {
+	var created []string
+	err := g.store.InTx(func(q Querier) error {
+		var locked bool
+		if err := q.QueryRow(`SELECT pg_try_advisory_xact_lock($1)`, autogenLockKey).Scan(&locked); err != nil {
+			return errors.Wrap(err, "taking the pool generation lock")
+		}
+		if !locked {
+			log.Info("pool generation already running elsewhere")
+			return nil
+		}
+
+		defaults, err := loadPoolDefaults(q)
+		if err != nil {
+			return err
+		}
+		now := g.now()
+		for _, poolType := range []Type{TypeH2H, TypeOverUnder} {
+			for game, gameDefaults := range defaults[poolType] {
+				matches, err := loadCandidateMatches(q, game, poolType, now.Add(g.config.Lead), now.Add(g.config.Window))
+				if err != nil {
+					return err
+				}
+				ids, err := generatePools(q, gameDefaults, matches)
+				if err != nil {
+					return err
+				}
+				created = append(created, ids...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(created) > 0 {
+		log.WithField("pools", len(created)).Info("generated pools")
+	}
+	return created, nil
+}
+
+// poolDefaultAmounts names the pool_defaults amount columns in the order
+// loadPoolDefaults reads them.
+var poolDefaultAmounts = []string{
+	"guarantee", "carry_in", "allocation", "unit_value",
+	"min_unit_per_line", "max_unit_per_line", "min_unit_per_ticket", "max_unit_per_ticket",
+}
+
+// nullableDefaultAmounts are the amounts that read as zero when NULL.
+var nullableDefaultAmounts = map[string]bool{"guarantee": true, "carry_in": true}
+
+// loadPoolDefaults returns the pool defaults by type and game, largest leg
+// count first. Rows missing a game, type or required amount or with an
+// unknown currency are skipped with a warning so one incomplete default
+// does not stop the generator. A default without a currency uses
+// defaultCurrency.
+func loadPoolDefaults(q Querier) (map[Type]map[string][]*poolDefault, error) {
+	rows, err := q.Query(`
+		SELECT id, leg_count, game, type, guarantee, carry_in, allocation, unit_value,
+		       min_unit_per_line, max_unit_per_line, min_unit_per_ticket, max_unit_per_ticket,
+		       currency
+		FROM pool_defaults
+		WHERE leg_count > 0
+		ORDER BY leg_count DESC`)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading pool defaults")
+	}
+	defer rows.Close()
+
+	defaults := map[Type]map[string][]*poolDefault{}
+	for rows.Next() {
+		d := &poolDefault{}
+		var (
+			id             string
+			legCount       decimal.Decimal
+			game, poolType sql.NullString
+			currency       sql.NullString
+			amounts        [8]decimal.NullDecimal
+		)
+		if err := rows.Scan(&id, &legCount, &game, &poolType, &amounts[0], &amounts[1], &amounts[2], &amounts[3],
+			&amounts[4], &amounts[5], &amounts[6], &amounts[7], &currency); err != nil {
+			return nil, errors.Wrap(err, "scanning pool default")
+		}
+
+		var missing []string
+		if !game.Valid || game.String == "" {
+			missing = append(missing, "game")
+		}
+		if !poolType.Valid || poolType.String == "" {
+			missing = append(missing, "type")
+		}
+		targets := []*decimal.Decimal{
+			&d.Guarantee, &d.CarryIn, &d.Allocation, &d.UnitValue,
+			&d.MinUnitPerLine, &d.MaxUnitPerLine, &d.MinUnitPerTicket, &d.MaxUnitPerTicket,
+		}
+		for i, target := range targets {
+			if !amounts[i].Valid && !nullableDefaultAmounts[poolDefaultAmounts[i]] {
+				missing = append(missing, poolDefaultAmounts[i])
+			}
+			*target = amounts[i].Decimal
+		}
+		if len(missing) > 0 {
+			log.WithFields(log.Fields{"poolDefault": id, "missing": missing}).Warn("skipping incomplete pool default")
+			continue
+		}
+		d.Currency = defaultCurrency
+		if currency.String != "" {
+			d.Currency = Currency(currency.String)
+		}
+		if !d.Currency.IsValid() {
+			log.WithFields(log.Fields{"poolDefault": id, "currency": d.Currency}).Warn("skipping pool default with unknown currency")
+			continue
+		}
+
+		d.LegCount = int(legCount.IntPart())
+		d.Game = game.String
+		d.Type = Type(poolType.String)
+		if defaults[d.Type] == nil {
+			defaults[d.Type] = map[string][]*poolDefault{}
+		}
+		defaults[d.Type][d.Game] = append(defaults[d.Type][d.Game], d)
+	}
+	return defaults, errors.Wrap(rows.Err(), "reading pool defaults")
+}
+
+// loadCandidateMatches returns the active, scheduled matches of game
+// starting in [from, to) that no live pool of poolType uses yet.
+func loadCandidateMatches(q Querier, game string, poolType Type, from, to time.Time) ([]*candidateMatch, error) {
+	rows, err := q.Query(`
+		SELECT m.id, e.game, coalesce(m.format, 'UNKNOWN'), m.start_time,
+		       coalesce(m.team_win_probabilities, '[]')
+		FROM matches m
+		JOIN events e ON e.id = m.event_id
+		WHERE e.game = $1
+		  AND e.is_active
+		  AND m.is_active
+		  AND m.internal_status = 'SCHEDULED'
+		  AND m.start_time >= $2 AND m.start_time < $3
+		  AND NOT EXISTS (
+		    SELECT 1 FROM legs l JOIN pools p ON p.id = l.pool_id
+		    WHERE l.match_id = m.id AND p.type = $4 AND p.synced_colossus_status IS DISTINCT FROM $5
+		  )
+		ORDER BY m.start_time, m.id`,
+		game, from, to, string(poolType), string(StatusAbandoned))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading upcoming %s matches", game)
+	}
+	defer rows.Close()
+
+	var matches []*candidateMatch
+	for rows.Next() {
+		m := &candidateMatch{}
+		var probabilities []byte
+		if err := rows.Scan(&m.ID, &m.Game, &m.Format, &m.StartTime, &probabilities); err != nil {
+			return nil, errors.Wrap(err, "scanning match")
+		}
+		if m.Probabilities, err = decodeWinProbabilities(probabilities); err != nil {
+			return nil, errors.Wrapf(err, "decoding win probabilities of match %s", m.ID)
+		}
+		matches = append(matches, m)
+	}
+	return matches, errors.Wrap(rows.Err(), "reading matches")
+}
+
+// generatePools cuts matches, in start time order, into pools using the
+// largest leg count that still fits.
+func generatePools(q Querier, defaults []*poolDefault, matches []*candidateMatch) ([]string, error) {
+	if len(defaults) == 0 {
+		return nil, nil
+	}
+	poolType := defaults[0].Type
+
+	var thresholds map[string]*ThresholdChoice
+	if poolType == TypeOverUnder {
+		table, err := loadThresholdTable(q, defaults[0].Game)
+		if err != nil {
+			return nil, err
+		}
+		thresholds = map[string]*ThresholdChoice{}
+		eligible := matches[:0]
+		for _, m := range matches {
+			if choice, ok := table.choose(m.Format, m.Probabilities); ok {
+				thresholds[m.ID] = choice
+				eligible = append(eligible, m)
+			}
+		}
+		matches = eligible
+	}
+
+	var created []string
+	for len(matches) > 0 {
+		var d *poolDefault
+		for _, candidate := range defaults {
+			if candidate.LegCount <= len(matches) {
+				d = candidate
+				break
+			}
+		}
+		if d == nil {
+			break
+		}
+		legs := matches[:d.LegCount]
+		matches = matches[d.LegCount:]
+
+		id, err := createPool(q, d, legs, thresholds)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, id)
+	}
+	return created, nil
+}
+
+// createPool creates a pool from d with a leg per match. thresholds holds
+// the over/under threshold per match id.
+func createPool(q Querier, d *poolDefault, matches []*candidateMatch, thresholds map[string]*ThresholdChoice) (string, error) {
+	sort.Slice(matches, func(i, j int) bool { return matches[i].StartTime.Before(matches[j].StartTime) })
+	name := fmt.Sprintf("%s %s %d legs %s", d.Game, d.Type, d.LegCount, matches[0].StartTime.UTC().Format("2006-01-02 15:04"))
+
+	rounding, err := LoadRounding(q, d.Currency)
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+	err = q.QueryRow(`
+		INSERT INTO pools (name, type, is_active, is_autogenerated, game, synced_colossus_status,
+		                   guarantee, carry_in, allocation, unit_value,
+		                   min_unit_per_line, max_unit_per_line, min_unit_per_ticket, max_unit_per_ticket,
+		                   currency, note)
+		VALUES ($1, $2, FALSE, TRUE, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, 'autogenerated')
+		RETURNING id`,
+		name, string(d.Type), d.Game, string(StatusNotReady),
+		d.Guarantee, d.CarryIn, d.Allocation, rounding.Round(d.UnitValue),
+		d.MinUnitPerLine, d.MaxUnitPerLine, d.MinUnitPerTicket, d.MaxUnitPerTicket,
+		string(d.Currency),
+	).Scan(&id)
+	if err != nil {
+		return "", errors.Wrapf(err, "creating pool %s", name)
+	}
+
+	for _, m := range matches {
+		var (
+			threshold, probability decimal.NullDecimal
+			rule                   sql.NullString
+		)
+		if choice, ok := thresholds[m.ID]; ok {
+			threshold = decimal.NullDecimal{Decimal: choice.Threshold, Valid: true}
+			probability = nullDecimal(choice.Probability)
+			rule = nullString(string(choice.Rule))
+		}
+		_, err := q.Exec(`
+			INSERT INTO legs (pool_id, match_id, threshold, threshold_rule, threshold_probability)
+			VALUES ($1, $2, $3, $4, $5)`, id, m.ID, threshold, rule, probability)
+		if err != nil {
+			return "", errors.Wrapf(err, "adding match %s to pool %s", m.ID, id)
+		}
+	}
+
+	if _, err := TransitionStatus(q, id, StatusNeedsApproval, "", "autogenerated"); err != nil {
+		return "", err
+	}
+	return id, nil
+}
@@ -0,0 +1,376 @@
+package pools
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// poolSpec is what a new pool is created from.
+type poolSpec struct {
+	Name             string
+	Type             Type
+	Game             string
+	Note             string
+	Currency         Currency
+	Guarantee        decimal.Decimal
+	Allocation       decimal.Decimal
+	UnitValue        decimal.Decimal
+	MinUnitPerLine   decimal.Decimal
+	MaxUnitPerLine   decimal.Decimal
+	MinUnitPerTicket decimal.Decimal
+	MaxUnitPerTicket decimal.Decimal
+	Consolations     []ConsolationPrize
+}
+
+// PoolOverrides replaces settings of a cloned or templated pool. Nil fields
+// keep the source's value. MatchIDs, when not nil, become the legs of the
+// new pool instead of the source pool's legs.
+type PoolOverrides struct {
+	Name             *string
+	Game             *string
+	Note             *string
+	Currency         *Currency
+	Guarantee        *decimal.Decimal
+	Allocation       *decimal.Decimal
+	UnitValue        *decimal.Decimal
+	MinUnitPerLine   *decimal.Decimal
+	MaxUnitPerLine   *decimal.Decimal
+	MinUnitPerTicket *decimal.Decimal
+	MaxUnitPerTicket *decimal.Decimal
+	MatchIDs         []string
+}
+
+func (o *PoolOverrides) apply(s *poolSpec) {
+	if o == nil {
+		return
+	}
+	if o.Name != nil {
+		s.Name = *o.Name
+	}
+	if o.Game != nil {
+		s.Game = *o.Game
+	}
+	if o.Note != nil {
+		s.Note = *o.Note
+	}
+	if o.Currency != nil {
+		s.Currency = *o.Currency
+	}
+	amounts := []struct {
+		override *decimal.Decimal
+		target   *decimal.Decimal
+	}{
+		{o.Guarantee, &s.Guarantee},
+		{o.Allocation, &s.Allocation},
+		{o.UnitValue, &s.UnitValue},
+		{o.MinUnitPerLine, &s.MinUnitPerLine},
+		{o.MaxUnitPerLine, &s.MaxUnitPerLine},
+		{o.MinUnitPerTicket, &s.MinUnitPerTicket},
+		{o.MaxUnitPerTicket, &s.MaxUnitPerTicket},
+	}
+	for _, a := range amounts {
+		if a.override != nil {
+			*a.target = *a.override
+		}
+	}
+}
+
+// withoutCarryIn returns prizes with their carry-in cleared. Carry-in is
+// money rolled into one particular pool and is never copied.
+func withoutCarryIn(prizes []ConsolationPrize) []ConsolationPrize {
+	cleared := make([]ConsolationPrize, len(prizes))
+	for i, prize := range prizes {
+		cleared[i] = ConsolationPrize{Guarantee: prize.Guarantee, Allocation: prize.Allocation, CarryIn: decimal.Zero}
+	}
+	return cleared
+}
+
+// insertPool creates an inactive NOT_READY pool from s and audits its
+// creation by userID.
+func insertPool(q Querier, s *poolSpec, userID string, audit interface{}) (string, error) {
+	if s.Game == "" {
+		return "", errors.Errorf("pool %s needs a game", s.Name)
+	}
+	consolations, err := json.Marshal(withoutCarryIn(s.Consolations))
+	if err != nil {
+		return "", errors.Wrap(err, "encoding consolation prizes")
+	}
+	var id string
+	err = q.QueryRow(`
+		INSERT INTO pools (name, type, is_active, is_autogenerated, game, synced_colossus_status,
+		                   guarantee, carry_in, allocation, unit_value,
+		                   min_unit_per_line, max_unit_per_line, min_unit_per_ticket, max_unit_per_ticket,
+		                   currency, note, consolations)
+		VALUES ($1, $2, FALSE, FALSE, $3, $4, $5, 0, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id`,
+		s.Name, string(s.Type), s.Game, string(StatusNotReady),
+		s.Guarantee, s.Allocation, s.UnitValue,
+		s.MinUnitPerLine, s.MaxUnitPerLine, s.MinUnitPerTicket, s.MaxUnitPerTicket,
+		string(s.Currency), s.Note, string(consolations),
+	).Scan(&id)
+	if err != nil {
+		return "", errors.Wrapf(err, "creating pool %s", s.Name)
+	}
+	if err := writeAudit(q, id, userID, "CREATE", audit); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// addLegs adds a primary leg per match to pool id. Over/under legs get
+// their threshold from ChooseThreshold.
+func addLegs(q Querier, id string, poolType Type, matchIDs []string) error {
+	for _, matchID := range matchIDs {
+		var (
+			threshold, probability decimal.NullDecimal
+			rule                   sql.NullString
+		)
+		if poolType == TypeOverUnder {
+			choice, err := ChooseThreshold(q, matchID)
+			if err != nil {
+				return err
+			}
+			threshold = decimal.NullDecimal{Decimal: choice.Threshold, Valid: true}
+			probability = nullDecimal(choice.Probability)
+			rule = nullString(string(choice.Rule))
+		}
+		_, err := q.Exec(`
+			INSERT INTO legs (pool_id, match_id, threshold, threshold_rule, threshold_probability)
+			VALUES ($1, $2, $3, $4, $5)`, id, matchID, threshold, rule, probability)
+		if err != nil {
+			return errors.Wrapf(err, "adding match %s to pool %s", matchID, id)
+		}
+	}
+	return nil
+}
+
+// ClonePool creates a new NOT_READY pool with the type, game and money
+// settings of pool id, including its consolation tiers, changed by
+// overrides. Without overrides.MatchIDs the primary and reserve legs are
+// copied with their thresholds and players. Carry-in, series membership
+// and status are not copied.
+func ClonePool(q Querier, id string, overrides *PoolOverrides, userID string) (string, error) {
+	var name, poolType, game, note sql.NullString
+	err := q.QueryRow(`SELECT name, type, game, note FROM pools WHERE id = $1`, id).Scan(&name, &poolType, &game, &note)
+	if err == sql.ErrNoRows {
+		return "", errors.Errorf("pool %s not found", id)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "reading pool %s", id)
+	}
+	m, err := LoadMoneySettings(q, id)
+	if err != nil {
+		return "", err
+	}
+	spec := &poolSpec{
+		Name:             name.String + " (copy)",
+		Type:             Type(poolType.String),
+		Game:             game.String,
+		Note:             note.String,
+		Currency:         m.Currency,
+		Guarantee:        m.Guarantee,
+		Allocation:       m.Allocation,
+		UnitValue:        m.UnitValue,
+		MinUnitPerLine:   m.MinUnitPerLine,
+		MaxUnitPerLine:   m.MaxUnitPerLine,
+		MinUnitPerTicket: m.MinUnitPerTicket,
+		MaxUnitPerTicket: m.MaxUnitPerTicket,
+		Consolations:     m.Consolations,
+	}
+	overrides.apply(spec)
+
+	clone, err := insertPool(q, spec, userID, map[string]string{"clonedFrom": id})
+	if err != nil {
+		return "", err
+	}
+	if overrides != nil && overrides.MatchIDs != nil {
+		err = addLegs(q, clone, spec.Type, overrides.MatchIDs)
+	} else {
+		_, err = q.Exec(`
+			INSERT INTO legs (pool_id, match_id, threshold, threshold_rule, threshold_probability, player_id, reserve_position)
+			SELECT $2, match_id, threshold, threshold_rule, threshold_probability, player_id, reserve_position
+			FROM legs
+			WHERE pool_id = $1 AND replaced_by IS NULL`, id, clone)
+		err = errors.Wrapf(err, "copying legs of pool %s", id)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	log.WithFields(log.Fields{"pool": clone, "source": id, "user": userID}).Info("pool cloned")
+	return clone, nil
+}
+
+// Template is a named, reusable set of pool settings. Unlike a PoolDefault
+// it is not used by the generator and need not be tied to a game.
+type Template struct {
+	ID                string             `json:"id"`
+	Name              string             `json:"name"`
+	Type              Type               `json:"type"`
+	Game              string             `json:"game"`
+	Currency          Currency           `json:"currency"`
+	Guarantee         decimal.Decimal    `json:"guarantee"`
+	Allocation        decimal.Decimal    `json:"allocation"`
+	UnitValue         decimal.Decimal    `json:"unitValue"`
+	MinUnitPerLine    decimal.Decimal    `json:"minUnitPerLine"`
+	MaxUnitPerLine    decimal.Decimal    `json:"maxUnitPerLine"`
+	MinUnitPerTicket  decimal.Decimal    `json:"minUnitPerTicket"`
+	MaxUnitPerTicket  decimal.Decimal    `json:"maxUnitPerTicket"`
+	ConsolationPrizes []ConsolationPrize `json:"consolationPrizes"`
+	Note              string             `json:"note"`
+	CreatedAt         time.Time          `json:"createdAt"`
+}
+
+const templateColumns = `id, name, type, coalesce(game, ''), currency, guarantee, allocation, unit_value,
+	min_unit_per_line, max_unit_per_line, min_unit_per_ticket, max_unit_per_ticket,
+	coalesce(consolations, '[]'), coalesce(note, ''), created_at`
+
+func scanTemplate(row interface{ Scan(...interface{}) error }) (*Template, error) {
+	t := &Template{}
+	var consolations []byte
+	err := row.Scan(&t.ID, &t.Name, &t.Type, &t.Game, &t.Currency, &t.Guarantee, &t.Allocation, &t.UnitValue,
+		&t.MinUnitPerLine, &t.MaxUnitPerLine, &t.MinUnitPerTicket, &t.MaxUnitPerTicket,
+		&consolations, &t.Note, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(consolations, &t.ConsolationPrizes); err != nil {
+		return nil, errors.Wrapf(err, "decoding consolation prizes of template %s", t.ID)
+	}
+	return t, nil
+}
+
+// CreateTemplate saves t and sets its ID and CreatedAt. Template names are
+// unique.
+func CreateTemplate(q Querier, t *Template) error {
+	if !t.Currency.IsValid() {
+		return errors.Errorf("%q is not a valid PoolCurrency for pool template %s", t.Currency, t.Name)
+	}
+	consolations, err := json.Marshal(withoutCarryIn(t.ConsolationPrizes))
+	if err != nil {
+		return errors.Wrap(err, "encoding consolation prizes")
+	}
+	err = q.QueryRow(`
+		INSERT INTO pool_templates (name, type, game, currency, guarantee, allocation, unit_value,
+		                            min_unit_per_line, max_unit_per_line, min_unit_per_ticket, max_unit_per_ticket,
+		                            consolations, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, created_at`,
+		t.Name, string(t.Type), nullString(t.Game), string(t.Currency), t.Guarantee, t.Allocation, t.UnitValue,
+		t.MinUnitPerLine, t.MaxUnitPerLine, t.MinUnitPerTicket, t.MaxUnitPerTicket,
+		string(consolations), t.Note,
+	).Scan(&t.ID, &t.CreatedAt)
+	return errors.Wrapf(err, "creating pool template %s", t.Name)
+}
+
+// SaveAsTemplate stores the settings and note of pool id as template name.
+// The template keeps the pool's game only when keepGame is set.
+func SaveAsTemplate(q Querier, id, name string, keepGame bool) (*Template, error) {
+	var poolType, game, note sql.NullString
+	err := q.QueryRow(`SELECT type, game, note FROM pools WHERE id = $1`, id).Scan(&poolType, &game, &note)
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("pool %s not found", id)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading pool %s", id)
+	}
+	m, err := LoadMoneySettings(q, id)
+	if err != nil {
+		return nil, err
+	}
+	t := &Template{
+		Name:              name,
+		Type:              Type(poolType.String),
+		Currency:          m.Currency,
+		Guarantee:         m.Guarantee,
+		Allocation:        m.Allocation,
+		UnitValue:         m.UnitValue,
+		MinUnitPerLine:    m.MinUnitPerLine,
+		MaxUnitPerLine:    m.MaxUnitPerLine,
+		MinUnitPerTicket:  m.MinUnitPerTicket,
+		MaxUnitPerTicket:  m.MaxUnitPerTicket,
+		ConsolationPrizes: withoutCarryIn(m.Consolations),
+		Note:              note.String,
+	}
+	if keepGame {
+		t.Game = game.String
+	}
+	return t, CreateTemplate(q, t)
+}
+
+// LoadTemplate returns template id.
+func LoadTemplate(q Querier, id string) (*Template, error) {
+	t, err := scanTemplate(q.QueryRow(`SELECT `+templateColumns+` FROM pool_templates WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("pool template %s not found", id)
+	}
+	return t, errors.Wrapf(err, "reading pool template %s", id)
+}
+
+// Templates returns the pool templates by name.
+func Templates(q Querier) ([]*Template, error) {
+	rows, err := q.Query(`SELECT ` + templateColumns + ` FROM pool_templates ORDER BY name`)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading pool templates")
+	}
+	defer rows.Close()
+
+	var templates []*Template
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "scanning pool template")
+		}
+		templates = append(templates, t)
+	}
+	return templates, errors.Wrap(rows.Err(), "reading pool templates")
+}
+
+// DeleteTemplate removes template id. Pools created from it are unaffected.
+func DeleteTemplate(q Querier, id string) error {
+	_, err := q.Exec(`DELETE FROM pool_templates WHERE id = $1`, id)
+	return errors.Wrapf(err, "deleting pool template %s", id)
+}
+
+// CreateFromTemplate creates a NOT_READY pool from template id changed by
+// overrides, with a leg per overrides.MatchIDs. A template without a game
+// needs overrides.Game.
+func CreateFromTemplate(q Querier, id string, overrides *PoolOverrides, userID string) (string, error) {
+	t, err := LoadTemplate(q, id)
+	if err != nil {
+		return "", err
+	}
+	spec := &poolSpec{
+		Name:             t.Name,
+		Type:             t.Type,
+		Game:             t.Game,
+		Note:             t.Note,
+		Currency:         t.Currency,
+		Guarantee:        t.Guarantee,
+		Allocation:       t.Allocation,
+		UnitValue:        t.UnitValue,
+		MinUnitPerLine:   t.MinUnitPerLine,
+		MaxUnitPerLine:   t.MaxUnitPerLine,
+		MinUnitPerTicket: t.MinUnitPerTicket,
+		MaxUnitPerTicket: t.MaxUnitPerTicket,
+		Consolations:     t.ConsolationPrizes,
+	}
+	overrides.apply(spec)
+
+	pool, err := insertPool(q, spec, userID, map[string]string{"template": id})
+	if err != nil {
+		return "", err
+	}
+	if overrides != nil {
+		if err := addLegs(q, pool, spec.Type, overrides.MatchIDs); err != nil {
+			return "", err
+		}
+	}
+	log.WithFields(log.Fields{"pool": pool, "template": id, "user": userID}).Info("pool created from template")
+	return pool, nil
+}
@@ -0,0 +1,102 @@
+package pools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestPoolOverridesApply(t *testing.T) {
+	source := func() *poolSpec {
+		return &poolSpec{
+			Name:             "Weekend H2H (copy)",
+			Type:             TypeH2H,
+			Game:             "CSGO",
+			Note:             "from the weekend",
+			Currency:         CurrencySTR,
+			Guarantee:        mustDecimal("1000"),
+			Allocation:       mustDecimal("0.8"),
+			UnitValue:        mustDecimal("1"),
+			MinUnitPerLine:   mustDecimal("1"),
+			MaxUnitPerLine:   mustDecimal("10"),
+			MinUnitPerTicket: mustDecimal("1"),
+			MaxUnitPerTicket: mustDecimal("100"),
+		}
+	}
+	name, game, note := "Cup final", "DOTA2", ""
+	eur := CurrencyEUR
+	zero, half := decimal.Zero, mustDecimal("0.5")
+
+	tests := []struct {
+		name      string
+		overrides *PoolOverrides
+		change    func(s *poolSpec)
+	}{
+		{name: "nil overrides", change: func(s *poolSpec) {}},
+		{name: "empty overrides", overrides: &PoolOverrides{}, change: func(s *poolSpec) {}},
+		{
+			name:      "settings replaced",
+			overrides: &PoolOverrides{Name: &name, Game: &game, Currency: &eur, UnitValue: &half},
+			change: func(s *poolSpec) {
+				s.Name, s.Game, s.Currency, s.UnitValue = name, game, eur, half
+			},
+		},
+		{
+			name:      "zero values override",
+			overrides: &PoolOverrides{Note: &note, Guarantee: &zero, MinUnitPerTicket: &zero},
+			change: func(s *poolSpec) {
+				s.Note, s.Guarantee, s.MinUnitPerTicket = "", zero, zero
+			},
+		},
+		{
+			name:      "match ids leave settings alone",
+			overrides: &PoolOverrides{MatchIDs: []string{"m1", "m2"}},
+			change:    func(s *poolSpec) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, want := source(), source()
+			tt.overrides.apply(got)
+			tt.change(want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithoutCarryIn(t *testing.T) {
+	prizes := []ConsolationPrize{
+		{Guarantee: mustDecimal("100"), CarryIn: mustDecimal("25.5"), Allocation: mustDecimal("0.1")},
+		{Guarantee: decimal.Zero, CarryIn: mustDecimal("3"), Allocation: mustDecimal("0.05")},
+	}
+	cleared := withoutCarryIn(prizes)
+	if len(cleared) != len(prizes) {
+		t.Fatalf("got %d prizes, want %d", len(cleared), len(prizes))
+	}
+	for i, prize := range cleared {
+		if !prize.CarryIn.Equal(decimal.Zero) {
+			t.Errorf("prize %d carry-in = %s, want 0", i, prize.CarryIn)
+		}
+		if !prize.Guarantee.Equal(prizes[i].Guarantee) || !prize.Allocation.Equal(prizes[i].Allocation) {
+			t.Errorf("prize %d = %+v, want the guarantee and allocation of %+v", i, prize, prizes[i])
+		}
+	}
+	if !prizes[0].CarryIn.Equal(mustDecimal("25.5")) {
+		t.Errorf("source prize carry-in changed to %s", prizes[0].CarryIn)
+	}
+	if got := withoutCarryIn(nil); len(got) != 0 {
+		t.Errorf("withoutCarryIn(nil) = %v, want no prizes", got)
+	}
+}
+
+func TestCreateTemplateRejectsInvalidCurrency(t *testing.T) {
+	for _, currency := range []Currency{"", "BTC", "eur"} {
+		// The currency is checked before the database is touched.
+		if err := CreateTemplate(nil, &Template{Name: "Weekend", Type: TypeH2H, Currency: currency}); err == nil {
+			t.Errorf("CreateTemplate with currency %q succeeded", currency)
+		}
+	}
+}
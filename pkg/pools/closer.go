@@ -0,0 +1,157 @@
+package pools
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// closerLockKey serialises trading close runs across replicas.
+const closerLockKey = 4107365124
+
+// reasonAutoClose is the reason the closer records on its transitions.
+const reasonAutoClose = "automatic trading close"
+
+// notStartedMatchStatuses are the internal statuses of matches that have
+// not begun. A leg on any other status that is not void closes trading at
+// once.
+var notStartedMatchStatuses = []string{"NOT_READY", "SCHEDULED", "DELAYED"}
+
+// CloserConfig controls the trading close scheduler. Parse it with
+// github.com/caarlos0/env.
+type CloserConfig struct {
+	Interval time.Duration `env:"POOL_CLOSE_INTERVAL" envDefault:"30s"`
+	// Offset is how long before the first leg starts trading closes.
+	Offset time.Duration `env:"POOL_TRADING_CLOSE_OFFSET" envDefault:"5m"`
+}
+
+// Closer moves pools to TRADING_CLOSED shortly before their first leg
+// starts. It never reopens trading; that is left to a trader.
+type Closer struct {
+	store  *Store
+	config CloserConfig
+	now    func() time.Time
+}
+
+// NewCloser returns a Closer writing through store.
+func NewCloser(store *Store, config CloserConfig) *Closer {
+	return &Closer{store: store, config: config, now: time.Now}
+}
+
+// Run checks the trading pools every config.Interval until ctx is done.
+func (c *Closer) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.Check(); err != nil {
+			log.WithError(err).Error("trading close check failed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tradingPool is a TRADING_OPEN pool with the schedule of its legs.
+type tradingPool struct {
+	ID     string
+	Status Status
+	// FirstStart is the earliest start time of the legs that are not void,
+	// if any.
+	FirstStart pq.NullTime
+	// Started is set when a leg's match is already under way.
+	Started bool
+}
+
+// closeTarget returns the status p should move to at now, or "" to leave it.
+// Start times are read afresh on every run, so a rescheduled match moves the
+// close time with it. Void legs have no reliable start and are left to the
+// void rules; under SUBSTITUTE they are swapped for a reserve first.
+func closeTarget(p *tradingPool, now time.Time, offset time.Duration) Status {
+	due := p.Started || (p.FirstStart.Valid && !now.Before(p.FirstStart.Time.Add(-offset)))
+	if p.Status == StatusTradingOpen && due {
+		return StatusTradingClosed
+	}
+	return ""
+}
+
+// Check replaces void legs with reserves, then closes trading as the leg
+// schedules require and returns the ids of the pools it closed.
+func (c *Closer) Check() ([]string, error) {
+	var changed []string
+	err := c.store.InTx(func(q Querier) error {
+		var locked bool
+		if err := q.QueryRow(`SELECT pg_try_advisory_xact_lock($1)`, closerLockKey).Scan(&locked); err != nil {
+			return errors.Wrap(err, "taking the trading close lock")
+		}
+		if !locked {
+			log.Info("trading close check already running elsewhere")
+			return nil
+		}
+
+		// Swap void legs first so the reserves count towards the close time.
+		if _, err := SubstituteVoidLegs(q); err != nil {
+			return err
+		}
+		pools, err := loadTradingPools(q)
+		if err != nil {
+			return err
+		}
+		now := c.now()
+		for _, p := range pools {
+			to := closeTarget(p, now, c.config.Offset)
+			if to == "" {
+				continue
+			}
+			if _, err := TransitionStatus(q, p.ID, to, "", reasonAutoClose); err != nil {
+				return err
+			}
+			log.WithFields(log.Fields{
+				"pool":       p.ID,
+				"from":       p.Status,
+				"to":         to,
+				"firstStart": p.FirstStart.Time,
+				"started":    p.Started,
+				"offset":     c.config.Offset,
+			}).Info("automatic trading status change")
+			changed = append(changed, p.ID)
+		}
+		return nil
+	})
+	return changed, err
+}
+
+// loadTradingPools returns the TRADING_OPEN pools that have legs, with the
+// schedule of their primary legs.
+func loadTradingPools(q Querier) ([]*tradingPool, error) {
+	rows, err := q.Query(`
+		SELECT p.id, p.synced_colossus_status,
+		       min(m.start_time) FILTER (WHERE NOT (coalesce(m.internal_status, '') = ANY($2))),
+		       coalesce(bool_or(NOT (coalesce(m.internal_status, 'NOT_READY') = ANY($2))
+		                        AND NOT (coalesce(m.internal_status, 'NOT_READY') = ANY($3))), FALSE)
+		FROM pools p
+		JOIN legs l ON l.pool_id = p.id AND l.reserve_position IS NULL AND l.replaced_by IS NULL
+		JOIN matches m ON m.id = l.match_id
+		WHERE p.synced_colossus_status = $1
+		GROUP BY p.id`,
+		string(StatusTradingOpen), pq.Array(voidMatchStatuses), pq.Array(notStartedMatchStatuses))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading trading pools")
+	}
+	defer rows.Close()
+
+	var pools []*tradingPool
+	for rows.Next() {
+		p := &tradingPool{}
+		if err := rows.Scan(&p.ID, &p.Status, &p.FirstStart, &p.Started); err != nil {
+			return nil, errors.Wrap(err, "scanning trading pool")
+		}
+		pools = append(pools, p)
+	}
+	return pools, errors.Wrap(rows.Err(), "reading trading pools")
+}
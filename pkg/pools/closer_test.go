@@ -0,0 +1,42 @@
+package pools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestCloseTarget(t *testing.T) {
+	now := time.Date(2019, 6, 1, 18, 0, 0, 0, time.UTC)
+	offset := 5 * time.Minute
+	start := func(d time.Duration) pq.NullTime {
+		return pq.NullTime{Time: now.Add(d), Valid: true}
+	}
+
+	tests := []struct {
+		name string
+		pool tradingPool
+		want Status
+	}{
+		{name: "before the offset", pool: tradingPool{Status: StatusTradingOpen, FirstStart: start(offset + time.Second)}},
+		{name: "at the offset", pool: tradingPool{Status: StatusTradingOpen, FirstStart: start(offset)}, want: StatusTradingClosed},
+		{name: "inside the offset", pool: tradingPool{Status: StatusTradingOpen, FirstStart: start(time.Minute)}, want: StatusTradingClosed},
+		{name: "first leg started late", pool: tradingPool{Status: StatusTradingOpen, FirstStart: start(-time.Hour)}, want: StatusTradingClosed},
+		{name: "started without a start time", pool: tradingPool{Status: StatusTradingOpen, Started: true}, want: StatusTradingClosed},
+		{name: "started before its start time", pool: tradingPool{Status: StatusTradingOpen, FirstStart: start(time.Hour), Started: true}, want: StatusTradingClosed},
+		{name: "every leg void", pool: tradingPool{Status: StatusTradingOpen}},
+		{name: "already closed", pool: tradingPool{Status: StatusTradingClosed, FirstStart: start(-time.Hour), Started: true}},
+		{name: "visible", pool: tradingPool{Status: StatusVisible, FirstStart: start(time.Minute)}},
+		{name: "created", pool: tradingPool{Status: StatusCreated, Started: true}},
+		{name: "sync error", pool: tradingPool{Status: StatusSyncError, FirstStart: start(-time.Hour), Started: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := tt.pool
+			if got := closeTarget(&pool, now, offset); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package pools
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Currency mirrors the PoolCurrency GraphQL enum.
+type Currency string
+
+const (
+	CurrencySTR Currency = "STR"
+	CurrencyEUR Currency = "EUR"
+	CurrencyUSD Currency = "USD"
+	CurrencyGBP Currency = "GBP"
+)
+
+// defaultCurrency is the currency of pools and pool defaults that have
+// none, the one every pool used before currencies were configurable.
+const defaultCurrency = CurrencySTR
+
+// storedCurrency is the currency of a pools.currency value, defaultCurrency
+// when it is NULL or empty.
+func storedCurrency(c sql.NullString) Currency {
+	if c.String == "" {
+		return defaultCurrency
+	}
+	return Currency(c.String)
+}
+
+// IsValid reports whether c is one of the PoolCurrency values.
+func (c Currency) IsValid() bool {
+	switch c {
+	case CurrencySTR, CurrencyEUR, CurrencyUSD, CurrencyGBP:
+		return true
+	}
+	return false
+}
+
+// UnmarshalGQL implements the gqlgen Unmarshaler for the PoolCurrency enum.
+func (c *Currency) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("PoolCurrency must be a string")
+	}
+	*c = Currency(str)
+	if !c.IsValid() {
+		return fmt.Errorf("%s is not a valid PoolCurrency", str)
+	}
+	return nil
+}
+
+// MarshalGQL implements the gqlgen Marshaler for the PoolCurrency enum.
+func (c Currency) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(c)))
+}
+
+// RoundingMode mirrors the RoundingMode GraphQL enum.
+type RoundingMode string
+
+const (
+	RoundDown   RoundingMode = "DOWN"
+	RoundUp     RoundingMode = "UP"
+	RoundHalfUp RoundingMode = "HALF_UP"
+)
+
+// UnmarshalGQL implements the gqlgen Unmarshaler for the RoundingMode enum.
+func (m *RoundingMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("RoundingMode must be a string")
+	}
+	*m = RoundingMode(str)
+	return nil
+}
+
+// MarshalGQL implements the gqlgen Marshaler for the RoundingMode enum.
+func (m RoundingMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(m)))
+}
+
+// CurrencyRounding is how amounts in a currency are rounded: to Places
+// decimal places using Mode.
+type CurrencyRounding struct {
+	Currency Currency     `json:"currency"`
+	Places   int32        `json:"places"`
+	Mode     RoundingMode `json:"mode"`
+	Note     string       `json:"note"`
+}
+
+// defaultRounding applies to currencies without a currencies row.
+func defaultRounding(c Currency) *CurrencyRounding {
+	return &CurrencyRounding{Currency: c, Places: 2, Mode: RoundHalfUp}
+}
+
+// Round rounds d by r. Amounts are positive, so HALF_UP rounds halves away
+// from zero.
+func (r *CurrencyRounding) Round(d decimal.Decimal) decimal.Decimal {
+	switch r.Mode {
+	case RoundDown:
+		return d.Truncate(r.Places)
+	case RoundUp:
+		return d.Mul(decimal.New(1, r.Places)).Ceil().Mul(decimal.New(1, -r.Places))
+	default:
+		return d.Round(r.Places)
+	}
+}
+
+// CurrencyRate is the value of one unit of From in To. UpdatedAt is nil for
+// the rate of a currency to itself.
+type CurrencyRate struct {
+	From      Currency        `json:"from"`
+	To        Currency        `json:"to"`
+	Rate      decimal.Decimal `json:"rate"`
+	UpdatedAt *time.Time      `json:"updatedAt"`
+}
+
+// LoadRounding returns the rounding of currency c.
+func LoadRounding(q Querier, c Currency) (*CurrencyRounding, error) {
+	r := &CurrencyRounding{Currency: c}
+	err := q.QueryRow(`SELECT places, mode, coalesce(note, '') FROM currencies WHERE code = $1`, string(c)).
+		Scan(&r.Places, &r.Mode, &r.Note)
+	if err == sql.ErrNoRows {
+		return defaultRounding(c), nil
+	}
+	return r, errors.Wrapf(err, "reading rounding of %s", c)
+}
+
+// Roundings returns the configured currency roundings.
+func Roundings(q Querier) ([]*CurrencyRounding, error) {
+	rows, err := q.Query(`SELECT code, places, mode, coalesce(note, '') FROM currencies ORDER BY code`)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading currencies")
+	}
+	defer rows.Close()
+
+	var roundings []*CurrencyRounding
+	for rows.Next() {
+		r := &CurrencyRounding{}
+		if err := rows.Scan(&r.Currency, &r.Places, &r.Mode, &r.Note); err != nil {
+			return nil, errors.Wrap(err, "scanning currency")
+		}
+		roundings = append(roundings, r)
+	}
+	return roundings, errors.Wrap(rows.Err(), "reading currencies")
+}
+
+// SetRounding creates or replaces the rounding of r.Currency.
+func SetRounding(q Querier, r *CurrencyRounding) error {
+	if r.Places < 0 {
+		return errors.Errorf("places must not be negative, got %d", r.Places)
+	}
+	_, err := q.Exec(`
+		INSERT INTO currencies (code, places, mode, note)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code) DO UPDATE
+		SET places = EXCLUDED.places, mode = EXCLUDED.mode, note = EXCLUDED.note`,
+		string(r.Currency), r.Places, string(r.Mode), r.Note)
+	return errors.Wrapf(err, "saving rounding of %s", r.Currency)
+}
+
+// Rate returns the rate converting from into to. A missing rate is derived
+// from the inverse one if that is configured.
+func Rate(q Querier, from, to Currency) (*CurrencyRate, error) {
+	if from == to {
+		return &CurrencyRate{From: from, To: to, Rate: decimal.New(1, 0)}, nil
+	}
+	r := &CurrencyRate{From: from, To: to}
+	var inverse bool
+	err := q.QueryRow(`
+		SELECT rate, updated_at, from_currency <> $1
+		FROM currency_rates
+		WHERE (from_currency = $1 AND to_currency = $2) OR (from_currency = $2 AND to_currency = $1)
+		ORDER BY from_currency <> $1
+		LIMIT 1`, string(from), string(to)).Scan(&r.Rate, &r.UpdatedAt, &inverse)
+	if err == sql.ErrNoRows {
+		return nil, &Error{Code: CodeMissingRate, Message: fmt.Sprintf("no rate from %s to %s", from, to)}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading rate from %s to %s", from, to)
+	}
+	if inverse {
+		r.Rate = decimal.New(1, 0).Div(r.Rate)
+	}
+	return r, nil
+}
+
+// Rates returns the configured currency rates.
+func Rates(q Querier) ([]*CurrencyRate, error) {
+	rows, err := q.Query(`SELECT from_currency, to_currency, rate, updated_at FROM currency_rates ORDER BY from_currency, to_currency`)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading currency rates")
+	}
+	defer rows.Close()
+
+	var rates []*CurrencyRate
+	for rows.Next() {
+		r := &CurrencyRate{}
+		if err := rows.Scan(&r.From, &r.To, &r.Rate, &r.UpdatedAt); err != nil {
+			return nil, errors.Wrap(err, "scanning currency rate")
+		}
+		rates = append(rates, r)
+	}
+	return rates, errors.Wrap(rows.Err(), "reading currency rates")
+}
+
+// SetRate creates or replaces the rate from r.From to r.To and sets
+// r.UpdatedAt.
+func SetRate(q Querier, r *CurrencyRate) error {
+	if r.From == r.To {
+		return errors.Errorf("cannot set a rate from %s to itself", r.From)
+	}
+	if r.Rate.Sign() <= 0 {
+		return errors.Errorf("rate must be positive, got %s", r.Rate)
+	}
+	err := q.QueryRow(`
+		INSERT INTO currency_rates (from_currency, to_currency, rate, updated_at)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT (from_currency, to_currency) DO UPDATE
+		SET rate = EXCLUDED.rate, updated_at = EXCLUDED.updated_at
+		RETURNING updated_at`, string(r.From), string(r.To), r.Rate).Scan(&r.UpdatedAt)
+	return errors.Wrapf(err, "saving rate from %s to %s", r.From, r.To)
+}
+
+// MoneyReport is a pool's guarantee and stake limits in a reporting
+// currency. Stake limits are the unit limits times the unit value.
+type MoneyReport struct {
+	PoolID                string            `json:"poolId"`
+	Currency              Currency          `json:"currency"`
+	ReportingCurrency     Currency          `json:"reportingCurrency"`
+	Rate                  *CurrencyRate     `json:"rate"`
+	Guarantee             decimal.Decimal   `json:"guarantee"`
+	CarryIn               decimal.Decimal   `json:"carryIn"`
+	ConsolationGuarantees []decimal.Decimal `json:"consolationGuarantees"`
+	UnitValue             decimal.Decimal   `json:"unitValue"`
+	MinStakePerLine       decimal.Decimal   `json:"minStakePerLine"`
+	MaxStakePerLine       decimal.Decimal   `json:"maxStakePerLine"`
+	MinStakePerTicket     decimal.Decimal   `json:"minStakePerTicket"`
+	MaxStakePerTicket     decimal.Decimal   `json:"maxStakePerTicket"`
+}
+
+// ReportMoney expresses the money settings of pool id in currency to,
+// rounded by the rounding of to.
+func ReportMoney(q Querier, id string, to Currency) (*MoneyReport, error) {
+	m, err := LoadMoneySettings(q, id)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := Rate(q, m.Currency, to)
+	if e, ok := err.(*Error); ok {
+		e.PoolID = id
+	}
+	if err != nil {
+		return nil, err
+	}
+	rounding, err := LoadRounding(q, to)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(d decimal.Decimal) decimal.Decimal {
+		return rounding.Round(d.Mul(rate.Rate))
+	}
+
+	r := &MoneyReport{
+		PoolID:                id,
+		Currency:              m.Currency,
+		ReportingCurrency:     to,
+		Rate:                  rate,
+		Guarantee:             convert(m.Guarantee),
+		CarryIn:               convert(m.CarryIn),
+		ConsolationGuarantees: []decimal.Decimal{},
+		UnitValue:             convert(m.UnitValue),
+		MinStakePerLine:       convert(m.MinUnitPerLine.Mul(m.UnitValue)),
+		MaxStakePerLine:       convert(m.MaxUnitPerLine.Mul(m.UnitValue)),
+		MinStakePerTicket:     convert(m.MinUnitPerTicket.Mul(m.UnitValue)),
+		MaxStakePerTicket:     convert(m.MaxUnitPerTicket.Mul(m.UnitValue)),
+	}
+	for _, c := range m.Consolations {
+		r.ConsolationGuarantees = append(r.ConsolationGuarantees, convert(c.Guarantee))
+	}
+	return r, nil
+}
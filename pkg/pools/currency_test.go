@@ -0,0 +1,79 @@
+package pools
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestCurrencyRoundingRound(t *testing.T) {
+	tests := []struct {
+		mode   RoundingMode
+		places int32
+		amount string
+		want   string
+	}{
+		{RoundDown, 2, "1.239", "1.23"},
+		{RoundDown, 2, "1.231", "1.23"},
+		{RoundDown, 0, "99.99", "99"},
+		{RoundDown, 2, "1.2", "1.2"},
+		{RoundUp, 2, "1.231", "1.24"},
+		{RoundUp, 2, "1.23", "1.23"},
+		{RoundUp, 0, "99.01", "100"},
+		{RoundHalfUp, 2, "1.235", "1.24"},
+		{RoundHalfUp, 2, "1.2349", "1.23"},
+		{RoundHalfUp, 0, "2.5", "3"},
+		{RoundHalfUp, 0, "2.49", "2"},
+		{RoundingMode(""), 2, "1.235", "1.24"},
+	}
+	for _, tt := range tests {
+		r := &CurrencyRounding{Currency: CurrencyEUR, Places: tt.places, Mode: tt.mode}
+		if got := r.Round(mustDecimal(tt.amount)); !got.Equal(mustDecimal(tt.want)) {
+			t.Errorf("%s to %d places: Round(%s) = %s, want %s", tt.mode, tt.places, tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestCurrencyUnmarshalGQL(t *testing.T) {
+	tests := []struct {
+		input interface{}
+		want  Currency
+		valid bool
+	}{
+		{"STR", CurrencySTR, true},
+		{"EUR", CurrencyEUR, true},
+		{"USD", CurrencyUSD, true},
+		{"GBP", CurrencyGBP, true},
+		{"", "", false},
+		{"eur", "", false},
+		{"BTC", "", false},
+		{42, "", false},
+	}
+	for _, tt := range tests {
+		var c Currency
+		err := c.UnmarshalGQL(tt.input)
+		if tt.valid != (err == nil) {
+			t.Errorf("UnmarshalGQL(%v) error = %v, want valid %v", tt.input, err, tt.valid)
+			continue
+		}
+		if tt.valid && c != tt.want {
+			t.Errorf("UnmarshalGQL(%v) = %s, want %s", tt.input, c, tt.want)
+		}
+	}
+}
+
+func TestStoredCurrency(t *testing.T) {
+	tests := []struct {
+		stored sql.NullString
+		want   Currency
+	}{
+		{sql.NullString{}, CurrencySTR},
+		{sql.NullString{Valid: true}, CurrencySTR},
+		{sql.NullString{String: "STR", Valid: true}, CurrencySTR},
+		{sql.NullString{String: "EUR", Valid: true}, CurrencyEUR},
+	}
+	for _, tt := range tests {
+		if got := storedCurrency(tt.stored); got != tt.want {
+			t.Errorf("storedCurrency(%+v) = %s, want %s", tt.stored, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package pools
+
+import "fmt"
+
+// Error codes reported in the "code" extension of GraphQL errors.
+const (
+	CodeInvalidStatus      = "INVALID_POOL_STATUS"
+	CodeIllegalTransition  = "ILLEGAL_POOL_STATUS_TRANSITION"
+	CodeStatusUnchanged    = "POOL_STATUS_UNCHANGED"
+	CodeNotSettleable      = "POOL_NOT_SETTLEABLE"
+	CodeMatchesNotFinal    = "MATCHES_NOT_FINAL"
+	CodeMissingMatchData   = "MISSING_MATCH_DATA"
+	CodeMissingThreshold   = "MISSING_THRESHOLD"
+	CodeInvalidThreshold   = "INVALID_THRESHOLD"
+	CodeMissingPlayer      = "MISSING_PLAYER"
+	CodeInvalidPayoutInput = "INVALID_PAYOUT_INPUT"
+	CodeSeriesMismatch     = "POOL_SERIES_MISMATCH"
+	CodeApprovalRequired   = "POOL_APPROVAL_REQUIRED"
+	CodeForbiddenRole      = "FORBIDDEN_ROLE"
+	CodeFourEyes           = "FOUR_EYES_VIOLATION"
+	CodeReasonRequired     = "REASON_REQUIRED"
+	CodeValidationFailed   = "POOL_VALIDATION_FAILED"
+	CodeMissingRate        = "MISSING_CURRENCY_RATE"
+)
+
+// Error is a pool operation refused for a reason the client can act on.
+// gqlgen copies Extensions into the GraphQL error.
+type Error struct {
+	Code    string
+	PoolID  string
+	Message string
+	Details map[string]interface{}
+}
+
+func newError(code, poolID, format string, args ...interface{}) *Error {
+	return &Error{Code: code, PoolID: poolID, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("pool %s: %s", e.PoolID, e.Message)
+}
+
+// Extensions implements gqlgen's graphql.ExtendedError.
+func (e *Error) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": e.Code, "poolId": e.PoolID}
+	for k, v := range e.Details {
+		ext[k] = v
+	}
+	return ext
+}
@@ -0,0 +1,254 @@
+package pools
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ScoringRule awards Points for every unit of Stat a player records in a
+// match of Game.
+type ScoringRule struct {
+	ID     string          `json:"id"`
+	Game   string          `json:"game"`
+	Stat   string          `json:"stat"`
+	Points decimal.Decimal `json:"points"`
+	Note   string          `json:"note"`
+}
+
+// FantasyStat is the contribution of one statistic to a fantasy score.
+type FantasyStat struct {
+	Stat   string          `json:"stat"`
+	Value  decimal.Decimal `json:"value"`
+	Points decimal.Decimal `json:"points"`
+}
+
+// FantasyScore is the fantasy points a player earned in a match.
+type FantasyScore struct {
+	PlayerID  string          `json:"playerId"`
+	MatchID   string          `json:"matchId"`
+	Points    decimal.Decimal `json:"points"`
+	Breakdown []FantasyStat   `json:"breakdown"`
+}
+
+// ScoringRules returns the fantasy scoring rules of game, ordered by stat.
+func ScoringRules(q Querier, game string) ([]*ScoringRule, error) {
+	rows, err := q.Query(`
+		SELECT id, game, stat, points, coalesce(note, '')
+		FROM fantasy_scoring_rules
+		WHERE game = $1
+		ORDER BY stat`, game)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading fantasy scoring rules of %s", game)
+	}
+	defer rows.Close()
+
+	var rules []*ScoringRule
+	for rows.Next() {
+		rule := &ScoringRule{}
+		if err := rows.Scan(&rule.ID, &rule.Game, &rule.Stat, &rule.Points, &rule.Note); err != nil {
+			return nil, errors.Wrap(err, "scanning fantasy scoring rule")
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errors.Wrap(rows.Err(), "reading fantasy scoring rules")
+}
+
+// playerStatistics are the per-player entries of matches.statistics:
+//
+//	{"players": [{"playerId": "…", "externalId": "…", "kills": 21, "deaths": 14}]}
+//
+// Players are matched on playerId, falling back to the feed's externalId.
+type playerStatistics []map[string]interface{}
+
+func decodePlayerStatistics(data []byte) (playerStatistics, error) {
+	var stats struct {
+		Players playerStatistics `json:"players"`
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&stats); err != nil {
+		return nil, err
+	}
+	return stats.Players, nil
+}
+
+// ids returns the player and external ids named in the statistics.
+func (s playerStatistics) ids() (ids, externalIDs []string) {
+	for _, entry := range s {
+		if id, _ := entry["playerId"].(string); id != "" {
+			ids = append(ids, id)
+		}
+		if id, _ := entry["externalId"].(string); id != "" {
+			externalIDs = append(externalIDs, id)
+		}
+	}
+	return ids, externalIDs
+}
+
+func (s playerStatistics) find(playerID, externalID string) (map[string]interface{}, bool) {
+	for _, entry := range s {
+		if id, _ := entry["playerId"].(string); id != "" && id == playerID {
+			return entry, true
+		}
+		if id, _ := entry["externalId"].(string); id != "" && id == externalID {
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
+// scorePlayer applies rules to one player's statistics. Statistics without
+// a rule are ignored and rules without a statistic count as zero.
+func scorePlayer(rules []*ScoringRule, entry map[string]interface{}) (decimal.Decimal, []FantasyStat, error) {
+	total := decimal.Zero
+	breakdown := make([]FantasyStat, 0, len(rules))
+	for _, rule := range rules {
+		raw, ok := entry[rule.Stat]
+		if !ok {
+			continue
+		}
+		number, ok := raw.(json.Number)
+		if !ok {
+			return decimal.Zero, nil, fmt.Errorf("statistic %s is %v, not a number", rule.Stat, raw)
+		}
+		value, err := decimal.NewFromString(number.String())
+		if err != nil {
+			return decimal.Zero, nil, errors.Wrapf(err, "statistic %s", rule.Stat)
+		}
+		points := value.Mul(rule.Points)
+		total = total.Add(points)
+		breakdown = append(breakdown, FantasyStat{Stat: rule.Stat, Value: value, Points: points})
+	}
+	return total, breakdown, nil
+}
+
+// FantasyPoints scores the players of match matchID with the rules of its
+// event's game. An empty playerID scores every known player in the match
+// statistics.
+func FantasyPoints(q Querier, matchID, playerID string) ([]*FantasyScore, error) {
+	var (
+		game string
+		data []byte
+	)
+	err := q.QueryRow(`
+		SELECT coalesce(e.game, ''), coalesce(m.statistics, '{}')
+		FROM matches m
+		JOIN events e ON e.id = m.event_id
+		WHERE m.id = $1`, matchID).Scan(&game, &data)
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("match %s not found", matchID)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading match %s", matchID)
+	}
+	stats, err := decodePlayerStatistics(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding statistics of match %s", matchID)
+	}
+	rules, err := ScoringRules(q, game)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, externalIDs := stats.ids()
+	if playerID != "" {
+		ids, externalIDs = []string{playerID}, nil
+	}
+	// A feed player has a row per match, each under that match's team, so
+	// the lookup is limited to the teams of this match.
+	rows, err := q.Query(`
+		SELECT p.id, coalesce(p.external_id, ''), t.match_id
+		FROM players p
+		JOIN teams t ON t.id = p.team_id
+		WHERE (p.id::text = ANY ($1) OR p.external_id = ANY ($2))
+		AND t.match_id = $3`,
+		pq.Array(ids), pq.Array(externalIDs), matchID)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading players")
+	}
+	defer rows.Close()
+
+	var players []*matchPlayer
+	for rows.Next() {
+		player := &matchPlayer{}
+		if err := rows.Scan(&player.ID, &player.ExternalID, &player.MatchID); err != nil {
+			return nil, errors.Wrap(err, "scanning player")
+		}
+		players = append(players, player)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading players")
+	}
+	return scorePlayers(matchID, rules, stats, players)
+}
+
+// matchPlayer is a player row and the match its team belongs to.
+type matchPlayer struct {
+	ID         string
+	ExternalID string
+	MatchID    string
+}
+
+// scorePlayers scores the players of match matchID that appear in its
+// statistics, highest score first. Players of other matches are skipped
+// even when they share an external id with a player of this match.
+func scorePlayers(matchID string, rules []*ScoringRule, stats playerStatistics, players []*matchPlayer) ([]*FantasyScore, error) {
+	var scores []*FantasyScore
+	for _, player := range players {
+		if player.MatchID != matchID {
+			continue
+		}
+		entry, ok := stats.find(player.ID, player.ExternalID)
+		if !ok {
+			continue
+		}
+		points, breakdown, err := scorePlayer(rules, entry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "scoring player %s in match %s", player.ID, matchID)
+		}
+		scores = append(scores, &FantasyScore{PlayerID: player.ID, MatchID: matchID, Points: points, Breakdown: breakdown})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Points.GreaterThan(scores[j].Points)
+	})
+	return scores, nil
+}
+
+// resolveFantasy scores the leg's player. A player missing from the match
+// statistics did not play, which voids the leg.
+func resolveFantasy(poolID string, rules []*ScoringRule, leg *settlementLeg) (*LegResult, error) {
+	result := &LegResult{LegID: leg.ID}
+	if !resultMatchStatuses[leg.MatchStatus] {
+		result.Outcome = OutcomeVoid
+		return result, nil
+	}
+	if leg.PlayerID == "" {
+		return nil, newError(CodeMissingPlayer, poolID, "fantasy leg %s has no player", leg.ID)
+	}
+	stats, err := decodePlayerStatistics(leg.Statistics)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding statistics of match %s", leg.MatchID)
+	}
+	entry, ok := stats.find(leg.PlayerID, leg.PlayerExternalID)
+	if !ok {
+		result.Outcome = OutcomeVoid
+		return result, nil
+	}
+	points, _, err := scorePlayer(rules, entry)
+	if err != nil {
+		return nil, newError(CodeMissingMatchData, poolID, "scoring player %s in match %s: %v", leg.PlayerID, leg.MatchID, err)
+	}
+	result.Outcome = OutcomeScored
+	result.Total = &points
+	return result, nil
+}
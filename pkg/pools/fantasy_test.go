@@ -0,0 +1,70 @@
+package pools
+
+import "testing"
+
+func TestScorePlayers(t *testing.T) {
+	rules := []*ScoringRule{
+		{Stat: "kills", Points: mustDecimal("1")},
+		{Stat: "deaths", Points: mustDecimal("-0.5")},
+	}
+	stats, err := decodePlayerStatistics([]byte(`{"players": [
+		{"externalId": "s1mple", "kills": 21, "deaths": 14},
+		{"playerId": "p-zywoo", "kills": 25, "deaths": 10},
+		{"externalId": "absent", "kills": 3}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		players []*matchPlayer
+		want    map[string]string
+	}{
+		{
+			name: "matched by id and external id",
+			players: []*matchPlayer{
+				{ID: "p-s1mple", ExternalID: "s1mple", MatchID: "m1"},
+				{ID: "p-zywoo", ExternalID: "zywoo", MatchID: "m1"},
+			},
+			want: map[string]string{"p-s1mple": "14", "p-zywoo": "20"},
+		},
+		{
+			name: "same external id in two matches",
+			players: []*matchPlayer{
+				{ID: "p-s1mple-m1", ExternalID: "s1mple", MatchID: "m1"},
+				{ID: "p-s1mple-m2", ExternalID: "s1mple", MatchID: "m2"},
+			},
+			want: map[string]string{"p-s1mple-m1": "14"},
+		},
+		{
+			name:    "player missing from the statistics",
+			players: []*matchPlayer{{ID: "p-bench", ExternalID: "bench", MatchID: "m1"}},
+			want:    map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores, err := scorePlayers("m1", rules, stats, tt.players)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(scores) != len(tt.want) {
+				t.Fatalf("got %d scores, want %d", len(scores), len(tt.want))
+			}
+			for i, score := range scores {
+				want, ok := tt.want[score.PlayerID]
+				if !ok {
+					t.Errorf("unexpected score for %s", score.PlayerID)
+					continue
+				}
+				if score.MatchID != "m1" || !score.Points.Equal(mustDecimal(want)) {
+					t.Errorf("%s in %s scored %s, want %s in m1", score.PlayerID, score.MatchID, score.Points, want)
+				}
+				if i > 0 && scores[i-1].Points.LessThan(score.Points) {
+					t.Errorf("scores are not ordered highest first")
+				}
+			}
+		})
+	}
+}
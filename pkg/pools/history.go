@@ -0,0 +1,144 @@
+package pools
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// StatusTransition is one row of pools.statusHistory.
+type StatusTransition struct {
+	ID     string    `json:"id"`
+	PoolID string    `json:"poolId"`
+	From   Status    `json:"from"`
+	To     Status    `json:"to"`
+	UserID string    `json:"userId"`
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// lockStatus reads the status of pool id and locks the row until the end
+// of the surrounding transaction.
+func lockStatus(q Querier, id string) (Status, error) {
+	var status sql.NullString
+	err := q.QueryRow(`SELECT synced_colossus_status FROM pools WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", errors.Errorf("pool %s not found", id)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "reading status of pool %s", id)
+	}
+	if !status.Valid || status.String == "" {
+		return StatusNotReady, nil
+	}
+	return Status(status.String), nil
+}
+
+// TransitionStatus moves pool id to status to and records who did it and
+// why. An empty userID records a system transition. Moving to the current
+// status fails with CodeStatusUnchanged. Run it inside a transaction so the row
+// lock holds until the change commits. Pools awaiting approval are only
+// approved through Approvals.Approve.
+func TransitionStatus(q Querier, id string, to Status, userID, reason string) (*StatusTransition, error) {
+	from, err := lockStatus(q, id)
+	if err != nil {
+		return nil, err
+	}
+	if from == StatusNeedsApproval && to == StatusApproved {
+		return nil, newError(CodeApprovalRequired, id, "pool must be approved with approvePool")
+	}
+	return transitionStatus(q, id, to, userID, reason)
+}
+
+func transitionStatus(q Querier, id string, to Status, userID, reason string) (*StatusTransition, error) {
+	from, err := lockStatus(q, id)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, newError(CodeStatusUnchanged, id, "pool is already %s", to)
+	}
+	var origin Status
+	if from == StatusSyncError {
+		if origin, err = syncErrorOrigin(q, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkTransition(id, from, to, origin); err != nil {
+		return nil, err
+	}
+
+	if _, err := q.Exec(`UPDATE pools SET synced_colossus_status = $2 WHERE id = $1`, id, string(to)); err != nil {
+		return nil, errors.Wrapf(err, "updating status of pool %s", id)
+	}
+	t := &StatusTransition{PoolID: id, From: from, To: to, UserID: userID, Reason: reason}
+	err = q.QueryRow(`
+		INSERT INTO pool_status_transitions (pool_id, from_status, to_status, user_id, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, time`,
+		id, string(from), string(to), nullString(userID), reason,
+	).Scan(&t.ID, &t.Time)
+	if err != nil {
+		return nil, errors.Wrapf(err, "recording status transition of pool %s", id)
+	}
+
+	log.WithFields(log.Fields{
+		"pool":   id,
+		"from":   from,
+		"to":     to,
+		"user":   userID,
+		"reason": reason,
+	}).Info("pool status changed")
+	return t, nil
+}
+
+// syncErrorOrigin returns the status pool id was in when it last moved to
+// SYNC_ERROR, or "" when its history has no such transition.
+func syncErrorOrigin(q Querier, id string) (Status, error) {
+	var origin Status
+	err := q.QueryRow(`
+		SELECT from_status FROM pool_status_transitions
+		WHERE pool_id = $1 AND to_status = $2
+		ORDER BY time DESC, id DESC
+		LIMIT 1`, id, string(StatusSyncError)).Scan(&origin)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return origin, errors.Wrapf(err, "reading status of pool %s before %s", id, StatusSyncError)
+}
+
+// TransitionStatus moves pool id to status to in its own transaction.
+func (s *Store) TransitionStatus(id string, to Status, userID, reason string) (*StatusTransition, error) {
+	var t *StatusTransition
+	err := s.InTx(func(q Querier) error {
+		var err error
+		t, err = TransitionStatus(q, id, to, userID, reason)
+		return err
+	})
+	return t, err
+}
+
+// StatusHistory returns the recorded transitions of pool id, oldest first.
+func StatusHistory(q Querier, id string) ([]*StatusTransition, error) {
+	rows, err := q.Query(`
+		SELECT id, pool_id, from_status, to_status, coalesce(user_id::text, ''), reason, time
+		FROM pool_status_transitions
+		WHERE pool_id = $1
+		ORDER BY time, id`, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading status history of pool %s", id)
+	}
+	defer rows.Close()
+
+	var history []*StatusTransition
+	for rows.Next() {
+		t := &StatusTransition{}
+		if err := rows.Scan(&t.ID, &t.PoolID, &t.From, &t.To, &t.UserID, &t.Reason, &t.Time); err != nil {
+			return nil, errors.Wrap(err, "scanning status transition")
+		}
+		history = append(history, t)
+	}
+	return history, errors.Wrap(rows.Err(), "reading status transitions")
+}
@@ -0,0 +1,69 @@
+package pools
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ConsolationPrize is one entry of the pools.consolations JSON.
+type ConsolationPrize struct {
+	Guarantee  decimal.Decimal `json:"guarantee"`
+	CarryIn    decimal.Decimal `json:"carryIn"`
+	Allocation decimal.Decimal `json:"allocation"`
+}
+
+// MoneySettings are the fund and stake settings of a pool.
+type MoneySettings struct {
+	PoolID           string
+	Currency         Currency
+	Guarantee        decimal.Decimal
+	CarryIn          decimal.Decimal
+	Allocation       decimal.Decimal
+	UnitValue        decimal.Decimal
+	MinUnitPerLine   decimal.Decimal
+	MaxUnitPerLine   decimal.Decimal
+	MinUnitPerTicket decimal.Decimal
+	MaxUnitPerTicket decimal.Decimal
+	Consolations     []ConsolationPrize
+}
+
+// LoadMoneySettings reads the money settings of pool id. Unset amounts
+// read as zero and an unset currency as defaultCurrency.
+func LoadMoneySettings(q Querier, id string) (*MoneySettings, error) {
+	m := &MoneySettings{PoolID: id}
+	var (
+		currency     sql.NullString
+		consolations []byte
+		amounts      [8]decimal.NullDecimal
+	)
+	err := q.QueryRow(`
+		SELECT currency, guarantee, carry_in, allocation, unit_value,
+		       min_unit_per_line, max_unit_per_line, min_unit_per_ticket, max_unit_per_ticket,
+		       coalesce(consolations, '[]')
+		FROM pools
+		WHERE id = $1`, id,
+	).Scan(&currency, &amounts[0], &amounts[1], &amounts[2], &amounts[3],
+		&amounts[4], &amounts[5], &amounts[6], &amounts[7], &consolations)
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("pool %s not found", id)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading money settings of pool %s", id)
+	}
+
+	m.Currency = storedCurrency(currency)
+	targets := []*decimal.Decimal{
+		&m.Guarantee, &m.CarryIn, &m.Allocation, &m.UnitValue,
+		&m.MinUnitPerLine, &m.MaxUnitPerLine, &m.MinUnitPerTicket, &m.MaxUnitPerTicket,
+	}
+	for i, target := range targets {
+		*target = amounts[i].Decimal
+	}
+	if err := json.Unmarshal(consolations, &m.Consolations); err != nil {
+		return nil, errors.Wrapf(err, "decoding consolation prizes of pool %s", id)
+	}
+	return m, nil
+}
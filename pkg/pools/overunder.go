@@ -0,0 +1,72 @@
+package pools
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+var half = decimal.New(5, -1)
+
+// thresholdKind tells how an over/under threshold splits the totals.
+type thresholdKind int
+
+const (
+	// halfPointThreshold ends in .5, so every total is strictly over or under.
+	halfPointThreshold thresholdKind = iota
+	// wholeThreshold is an integer; a total equal to it is a push.
+	wholeThreshold
+	// invalidThreshold has any other fraction and cannot be settled.
+	invalidThreshold
+)
+
+func classifyThreshold(threshold decimal.Decimal) thresholdKind {
+	fraction := threshold.Sub(threshold.Floor())
+	switch {
+	case fraction.Equal(decimal.Zero):
+		return wholeThreshold
+	case fraction.Equal(half):
+		return halfPointThreshold
+	default:
+		return invalidThreshold
+	}
+}
+
+// resolveOverUnder compares the summed over/under scores of the match with
+// the leg threshold. Half-point thresholds cannot push; whole-number
+// thresholds push when the total lands on them.
+func resolveOverUnder(poolID string, leg *settlementLeg) (*LegResult, error) {
+	result := &LegResult{LegID: leg.ID}
+	if !resultMatchStatuses[leg.MatchStatus] {
+		result.Outcome = OutcomeVoid
+		return result, nil
+	}
+	if !leg.Threshold.Valid {
+		return nil, newError(CodeMissingThreshold, poolID, "leg %s has no threshold", leg.ID)
+	}
+	threshold := leg.Threshold.Decimal
+	kind := classifyThreshold(threshold)
+	if kind == invalidThreshold {
+		return nil, newError(CodeInvalidThreshold, poolID, "leg %s threshold %s must be a whole or half-point number", leg.ID, threshold)
+	}
+	if len(leg.TeamOUScores) == 0 {
+		return nil, newError(CodeMissingMatchData, poolID, "match %s has no over/under scores", leg.MatchID)
+	}
+
+	total := 0
+	for _, score := range leg.TeamOUScores {
+		total += score.Score
+	}
+	sum := decimal.New(int64(total), 0)
+	result.Total = &sum
+	result.Threshold = &threshold
+
+	switch cmp := sum.Cmp(threshold); {
+	case cmp > 0:
+		result.Outcome = OutcomeOver
+	case cmp < 0:
+		result.Outcome = OutcomeUnder
+	default:
+		// Only reachable for whole-number thresholds.
+		result.Outcome = OutcomePush
+	}
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package pools
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestClassifyThreshold(t *testing.T) {
+	tests := []struct {
+		threshold string
+		want      thresholdKind
+	}{
+		{"0", wholeThreshold},
+		{"42", wholeThreshold},
+		{"42.0", wholeThreshold},
+		{"42.5", halfPointThreshold},
+		{"0.5", halfPointThreshold},
+		{"-1.5", halfPointThreshold},
+		{"42.25", invalidThreshold},
+		{"42.75", invalidThreshold},
+		{"42.05", invalidThreshold},
+	}
+	for _, tt := range tests {
+		if got := classifyThreshold(mustDecimal(tt.threshold)); got != tt.want {
+			t.Errorf("classifyThreshold(%s) = %d, want %d", tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestResolveOverUnder(t *testing.T) {
+	scores := func(totals ...int) []TeamScore {
+		var s []TeamScore
+		for i, total := range totals {
+			s = append(s, TeamScore{TeamID: string(rune('a' + i)), Score: total})
+		}
+		return s
+	}
+	tests := []struct {
+		name      string
+		status    string
+		threshold string
+		scores    []TeamScore
+		outcome   Outcome
+		total     int64
+		code      string
+	}{
+		{name: "over half point", status: "FINISHED", threshold: "20.5", scores: scores(12, 9), outcome: OutcomeOver, total: 21},
+		{name: "under half point", status: "CLOSED", threshold: "20.5", scores: scores(12, 8), outcome: OutcomeUnder, total: 20},
+		{name: "over whole", status: "FINISHED", threshold: "20", scores: scores(12, 9), outcome: OutcomeOver, total: 21},
+		{name: "under whole", status: "FINISHED", threshold: "20", scores: scores(10, 9), outcome: OutcomeUnder, total: 19},
+		{name: "push on whole", status: "FINISHED", threshold: "20", scores: scores(12, 8), outcome: OutcomePush, total: 20},
+		{name: "void match", status: "CANCELLED", threshold: "20", outcome: OutcomeVoid},
+		{name: "postponed match", status: "POSTPONED", threshold: "20", outcome: OutcomeVoid},
+		{name: "missing threshold", status: "FINISHED", scores: scores(1, 1), code: CodeMissingThreshold},
+		{name: "quarter threshold", status: "FINISHED", threshold: "20.25", scores: scores(1, 1), code: CodeInvalidThreshold},
+		{name: "missing scores", status: "FINISHED", threshold: "20.5", code: CodeMissingMatchData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leg := &settlementLeg{ID: "leg", MatchID: "match", MatchStatus: tt.status, TeamOUScores: tt.scores}
+			if tt.threshold != "" {
+				leg.Threshold = decimal.NullDecimal{Decimal: mustDecimal(tt.threshold), Valid: true}
+			}
+			result, err := resolveOverUnder("pool", leg)
+			if tt.code != "" {
+				if e, ok := err.(*Error); !ok || e.Code != tt.code {
+					t.Fatalf("got error %v, want code %s", err, tt.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Outcome != tt.outcome {
+				t.Errorf("got outcome %s, want %s", result.Outcome, tt.outcome)
+			}
+			if tt.outcome == OutcomeVoid {
+				if result.Total != nil {
+					t.Errorf("void leg has total %s", result.Total)
+				}
+				return
+			}
+			if result.Total == nil || !result.Total.Equal(decimal.New(tt.total, 0)) {
+				t.Errorf("got total %v, want %d", result.Total, tt.total)
+			}
+		})
+	}
+}
+
+func mustDecimal(s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
@@ -0,0 +1,146 @@
+package pools
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// dividendPlaces is the precision dividends are rounded down to. The
+// rounding breakage is carried over.
+const dividendPlaces = 2
+
+// PayoutInput is the trading outcome a payout is computed for.
+type PayoutInput struct {
+	// Sales is the total staked on the pool.
+	Sales decimal.Decimal
+	// Takeout is the operator's share of Sales, between 0 and 1.
+	Takeout decimal.Decimal
+	// WinningUnits holds the winning units of the main tier followed by
+	// one entry per consolation tier.
+	WinningUnits []decimal.Decimal
+}
+
+// TierPayout is the distribution of one prize tier. Tier 0 is the main
+// dividend, the others are the consolation prizes in order.
+type TierPayout struct {
+	Tier         int             `json:"tier"`
+	Allocation   decimal.Decimal `json:"allocation"`
+	Guarantee    decimal.Decimal `json:"guarantee"`
+	CarryIn      decimal.Decimal `json:"carryIn"`
+	Fund         decimal.Decimal `json:"fund"`
+	TopUp        decimal.Decimal `json:"topUp"`
+	WinningUnits decimal.Decimal `json:"winningUnits"`
+	Dividend     decimal.Decimal `json:"dividend"`
+	Paid         decimal.Decimal `json:"paid"`
+	CarryOver    decimal.Decimal `json:"carryOver"`
+}
+
+// Payout is the prize fund distribution of a pool.
+type Payout struct {
+	PoolID   string          `json:"poolId"`
+	Currency Currency        `json:"currency"`
+	Sales    decimal.Decimal `json:"sales"`
+	NetPool  decimal.Decimal `json:"netPool"`
+	// Unallocated is the part of NetPool no tier is allocated.
+	Unallocated decimal.Decimal `json:"unallocated"`
+	Tiers       []TierPayout    `json:"tiers"`
+	// GuaranteeTopUp is what the operator adds to meet the guarantees.
+	GuaranteeTopUp decimal.Decimal `json:"guaranteeTopUp"`
+	// CarryOver is the amount rolled into the next pool: the unallocated
+	// fund, the funds of tiers without winners and the rounding breakage.
+	CarryOver decimal.Decimal `json:"carryOver"`
+}
+
+// tiers returns the main tier followed by the consolation tiers.
+func (m *MoneySettings) tiers() []ConsolationPrize {
+	main := ConsolationPrize{Guarantee: m.Guarantee, CarryIn: m.CarryIn, Allocation: m.Allocation}
+	return append([]ConsolationPrize{main}, m.Consolations...)
+}
+
+// CalculatePayout distributes the net pool over the prize tiers of m.
+// Each tier gets its allocation of the net pool plus its carry-in, topped
+// up to its guarantee. A tier with winners pays the fund divided by the
+// winning units, rounded down; a tier without winners carries over what
+// it raised.
+func CalculatePayout(m *MoneySettings, in PayoutInput) (*Payout, error) {
+	tiers := m.tiers()
+	if len(in.WinningUnits) != len(tiers) {
+		return nil, newError(CodeInvalidPayoutInput, m.PoolID, "got winning units for %d tiers, pool has %d", len(in.WinningUnits), len(tiers))
+	}
+	if in.Sales.Sign() < 0 {
+		return nil, newError(CodeInvalidPayoutInput, m.PoolID, "sales %s are negative", in.Sales)
+	}
+	if in.Takeout.Sign() < 0 || in.Takeout.Cmp(decimal.New(1, 0)) >= 0 {
+		return nil, newError(CodeInvalidPayoutInput, m.PoolID, "takeout %s must be at least 0 and below 1", in.Takeout)
+	}
+
+	totalAllocation := decimal.Zero
+	for i, tier := range tiers {
+		if tier.Allocation.Sign() < 0 || tier.Guarantee.Sign() < 0 || tier.CarryIn.Sign() < 0 {
+			return nil, newError(CodeInvalidPayoutInput, m.PoolID, "tier %d has a negative amount", i)
+		}
+		if in.WinningUnits[i].Sign() < 0 {
+			return nil, newError(CodeInvalidPayoutInput, m.PoolID, "tier %d has negative winning units", i)
+		}
+		totalAllocation = totalAllocation.Add(tier.Allocation)
+	}
+	if totalAllocation.Cmp(decimal.New(1, 0)) > 0 {
+		return nil, newError(CodeInvalidPayoutInput, m.PoolID, "tier allocations add up to %s, more than 1", totalAllocation)
+	}
+
+	net := in.Sales.Sub(in.Sales.Mul(in.Takeout))
+	p := &Payout{
+		PoolID:         m.PoolID,
+		Currency:       m.Currency,
+		Sales:          in.Sales,
+		NetPool:        net,
+		Unallocated:    net.Sub(net.Mul(totalAllocation)),
+		GuaranteeTopUp: decimal.Zero,
+	}
+	p.CarryOver = p.Unallocated
+
+	for i, tier := range tiers {
+		t := TierPayout{
+			Tier:         i,
+			Allocation:   tier.Allocation,
+			Guarantee:    tier.Guarantee,
+			CarryIn:      tier.CarryIn,
+			Fund:         net.Mul(tier.Allocation).Add(tier.CarryIn),
+			TopUp:        decimal.Zero,
+			WinningUnits: in.WinningUnits[i],
+			Dividend:     decimal.Zero,
+			Paid:         decimal.Zero,
+		}
+		raised := t.Fund
+		if t.Fund.LessThan(t.Guarantee) {
+			t.Fund = t.Guarantee
+		}
+
+		// Guarantee money is only paid out, never rolled: the top-up covers
+		// what the dividends exceed the raised fund by, and only raised
+		// money carries over.
+		t.CarryOver = raised
+		if t.WinningUnits.Sign() > 0 {
+			t.Dividend = t.Fund.Div(t.WinningUnits).Truncate(dividendPlaces)
+			t.Paid = t.Dividend.Mul(t.WinningUnits)
+			if t.Paid.GreaterThan(raised) {
+				t.TopUp = t.Paid.Sub(raised)
+				t.CarryOver = decimal.Zero
+			} else {
+				t.CarryOver = raised.Sub(t.Paid)
+			}
+			p.GuaranteeTopUp = p.GuaranteeTopUp.Add(t.TopUp)
+		}
+		p.CarryOver = p.CarryOver.Add(t.CarryOver)
+		p.Tiers = append(p.Tiers, t)
+	}
+	return p, nil
+}
+
+// PayoutPreview computes the payout of pool id for a hypothetical outcome.
+func PayoutPreview(q Querier, id string, in PayoutInput) (*Payout, error) {
+	m, err := LoadMoneySettings(q, id)
+	if err != nil {
+		return nil, err
+	}
+	return CalculatePayout(m, in)
+}
@@ -0,0 +1,145 @@
+package pools
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculatePayout(t *testing.T) {
+	type tier struct {
+		dividend, paid, topUp, carryOver string
+	}
+	tests := []struct {
+		name         string
+		money        MoneySettings
+		sales        string
+		takeout      string
+		units        []string
+		tiers        []tier
+		topUp        string
+		carryOver    string
+		invalidInput bool
+	}{
+		{
+			name:      "truncated dividend carries the breakage",
+			money:     MoneySettings{Allocation: mustDecimal("1")},
+			sales:     "1000",
+			takeout:   "0.2",
+			units:     []string{"3"},
+			tiers:     []tier{{dividend: "266.66", paid: "799.98", topUp: "0", carryOver: "0.02"}},
+			topUp:     "0",
+			carryOver: "0.02",
+		},
+		{
+			name:      "guarantee topped up",
+			money:     MoneySettings{Allocation: mustDecimal("1"), Guarantee: mustDecimal("500")},
+			sales:     "100",
+			takeout:   "0.1",
+			units:     []string{"2"},
+			tiers:     []tier{{dividend: "250", paid: "500", topUp: "410", carryOver: "0"}},
+			topUp:     "410",
+			carryOver: "0",
+		},
+		{
+			name:      "guarantee is not carried without winners",
+			money:     MoneySettings{Allocation: mustDecimal("1"), Guarantee: mustDecimal("500")},
+			sales:     "100",
+			takeout:   "0.1",
+			units:     []string{"0"},
+			tiers:     []tier{{dividend: "0", paid: "0", topUp: "0", carryOver: "90"}},
+			topUp:     "0",
+			carryOver: "90",
+		},
+		{
+			name: "carry-in and consolation without winners",
+			money: MoneySettings{
+				Allocation:   mustDecimal("0.7"),
+				CarryIn:      mustDecimal("50"),
+				Consolations: []ConsolationPrize{{Allocation: mustDecimal("0.2")}},
+			},
+			sales:   "1000",
+			takeout: "0.2",
+			units:   []string{"4", "0"},
+			tiers: []tier{
+				{dividend: "152.5", paid: "610", topUp: "0", carryOver: "0"},
+				{dividend: "0", paid: "0", topUp: "0", carryOver: "160"},
+			},
+			topUp:     "0",
+			carryOver: "240",
+		},
+		{
+			name:         "tier count mismatch",
+			money:        MoneySettings{Allocation: mustDecimal("1")},
+			sales:        "100",
+			takeout:      "0.1",
+			units:        []string{"1", "1"},
+			invalidInput: true,
+		},
+		{
+			name:         "takeout of everything",
+			money:        MoneySettings{Allocation: mustDecimal("1")},
+			sales:        "100",
+			takeout:      "1",
+			units:        []string{"1"},
+			invalidInput: true,
+		},
+		{
+			name:         "negative sales",
+			money:        MoneySettings{Allocation: mustDecimal("1")},
+			sales:        "-1",
+			takeout:      "0.1",
+			units:        []string{"1"},
+			invalidInput: true,
+		},
+		{
+			name: "over-allocated",
+			money: MoneySettings{
+				Allocation:   mustDecimal("0.8"),
+				Consolations: []ConsolationPrize{{Allocation: mustDecimal("0.3")}},
+			},
+			sales:        "100",
+			takeout:      "0.1",
+			units:        []string{"1", "1"},
+			invalidInput: true,
+		},
+	}
+
+	equal := func(t *testing.T, field string, got decimal.Decimal, want string) {
+		t.Helper()
+		if !got.Equal(mustDecimal(want)) {
+			t.Errorf("%s = %s, want %s", field, got, want)
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := PayoutInput{Sales: mustDecimal(tt.sales), Takeout: mustDecimal(tt.takeout)}
+			for _, u := range tt.units {
+				in.WinningUnits = append(in.WinningUnits, mustDecimal(u))
+			}
+			money := tt.money
+			p, err := CalculatePayout(&money, in)
+			if tt.invalidInput {
+				if e, ok := err.(*Error); !ok || e.Code != CodeInvalidPayoutInput {
+					t.Fatalf("got error %v, want code %s", err, CodeInvalidPayoutInput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(p.Tiers) != len(tt.tiers) {
+				t.Fatalf("got %d tiers, want %d", len(p.Tiers), len(tt.tiers))
+			}
+			for i, want := range tt.tiers {
+				got := p.Tiers[i]
+				equal(t, "dividend", got.Dividend, want.dividend)
+				equal(t, "paid", got.Paid, want.paid)
+				equal(t, "top-up", got.TopUp, want.topUp)
+				equal(t, "tier carry-over", got.CarryOver, want.carryOver)
+			}
+			equal(t, "guarantee top-up", p.GuaranteeTopUp, tt.topUp)
+			equal(t, "carry-over", p.CarryOver, tt.carryOver)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package pools
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// auditSubstituteLeg is the audit edit action of a reserve leg swap.
+const auditSubstituteLeg = "SUBSTITUTE_LEG"
+
+// substitutableStatuses are the pool statuses in which a void leg may
+// still be swapped for a reserve.
+var substitutableStatuses = []string{
+	string(StatusNotReady),
+	string(StatusNeedsApproval),
+	string(StatusApproved),
+	string(StatusCreated),
+	string(StatusVisible),
+	string(StatusTradingOpen),
+}
+
+// LegSwap records a void primary leg replaced by a reserve leg.
+type LegSwap struct {
+	ID           string    `json:"id"`
+	PoolID       string    `json:"poolId"`
+	LegID        string    `json:"legId"`
+	ReserveLegID string    `json:"reserveLegId"`
+	MatchStatus  string    `json:"matchStatus"`
+	UserID       string    `json:"userId"`
+	Time         time.Time `json:"time"`
+}
+
+// voidLeg is a primary leg whose match was voided before trading closed.
+type voidLeg struct {
+	ID          string
+	PoolID      string
+	MatchID     string
+	MatchStatus string
+}
+
+// SubstituteVoidLegs replaces every void primary leg of the pools still
+// before trading close with the pool's next reserve leg, lowest
+// reserve_position first. Only pool types whose void rule is SUBSTITUTE
+// swap. A reserve whose match is voided itself or already a primary leg is
+// skipped. Legs without an eligible reserve stay void for settlement.
+func SubstituteVoidLegs(q Querier) ([]*LegSwap, error) {
+	legs, err := loadVoidLegs(q)
+	if err != nil {
+		return nil, err
+	}
+	rules := map[Type]*PoolVoidRule{}
+	var swaps []*LegSwap
+	for _, leg := range legs {
+		var poolType Type
+		if err := q.QueryRow(`SELECT type FROM pools WHERE id = $1 FOR UPDATE`, leg.PoolID).Scan(&poolType); err != nil {
+			return nil, errors.Wrapf(err, "reading pool %s", leg.PoolID)
+		}
+		rule, ok := rules[poolType]
+		if !ok {
+			if rule, err = LoadVoidRule(q, poolType); err != nil {
+				return nil, err
+			}
+			rules[poolType] = rule
+		}
+		if rule.Rule != VoidSubstitute {
+			continue
+		}
+		swap, err := substituteLeg(q, leg, "")
+		if err != nil {
+			return nil, err
+		}
+		if swap != nil {
+			swaps = append(swaps, swap)
+		}
+	}
+	return swaps, nil
+}
+
+func loadVoidLegs(q Querier) ([]*voidLeg, error) {
+	rows, err := q.Query(`
+		SELECT l.id, l.pool_id, l.match_id, m.internal_status
+		FROM legs l
+		JOIN matches m ON m.id = l.match_id
+		JOIN pools p ON p.id = l.pool_id
+		WHERE l.reserve_position IS NULL AND l.replaced_by IS NULL
+		  AND m.internal_status = ANY($1)
+		  AND coalesce(p.synced_colossus_status, $3) = ANY($2)
+		ORDER BY l.pool_id, m.start_time, l.id`,
+		pq.Array(voidMatchStatuses), pq.Array(substitutableStatuses), string(StatusNotReady))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading void legs")
+	}
+	defer rows.Close()
+
+	var legs []*voidLeg
+	for rows.Next() {
+		leg := &voidLeg{}
+		if err := rows.Scan(&leg.ID, &leg.PoolID, &leg.MatchID, &leg.MatchStatus); err != nil {
+			return nil, errors.Wrap(err, "scanning void leg")
+		}
+		legs = append(legs, leg)
+	}
+	return legs, errors.Wrap(rows.Err(), "reading void legs")
+}
+
+// substituteLeg promotes the next eligible reserve of leg's pool into its
+// place. It returns nil when the pool has no eligible reserve.
+func substituteLeg(q Querier, leg *voidLeg, userID string) (*LegSwap, error) {
+	var reserveID, reserveMatchID string
+	err := q.QueryRow(`
+		SELECT r.id, r.match_id
+		FROM legs r
+		JOIN matches m ON m.id = r.match_id
+		WHERE r.pool_id = $1
+		  AND r.reserve_position IS NOT NULL
+		  AND m.is_active
+		  AND coalesce(m.internal_status, '') = ANY($2)
+		  AND NOT EXISTS (
+		    SELECT 1 FROM legs l
+		    WHERE l.pool_id = r.pool_id AND l.match_id = r.match_id
+		      AND l.reserve_position IS NULL AND l.replaced_by IS NULL
+		  )
+		ORDER BY r.reserve_position
+		LIMIT 1
+		FOR UPDATE OF r`,
+		leg.PoolID, pq.Array(notStartedMatchStatuses),
+	).Scan(&reserveID, &reserveMatchID)
+	if err == sql.ErrNoRows {
+		log.WithFields(log.Fields{"pool": leg.PoolID, "leg": leg.ID, "match": leg.MatchID}).Debug("no reserve leg for void leg")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding a reserve leg for pool %s", leg.PoolID)
+	}
+
+	if _, err := q.Exec(`UPDATE legs SET reserve_position = NULL WHERE id = $1`, reserveID); err != nil {
+		return nil, errors.Wrapf(err, "promoting reserve leg %s", reserveID)
+	}
+	if _, err := q.Exec(`UPDATE legs SET replaced_by = $2 WHERE id = $1`, leg.ID, reserveID); err != nil {
+		return nil, errors.Wrapf(err, "replacing leg %s", leg.ID)
+	}
+
+	swap := &LegSwap{PoolID: leg.PoolID, LegID: leg.ID, ReserveLegID: reserveID, MatchStatus: leg.MatchStatus, UserID: userID}
+	err = q.QueryRow(`
+		INSERT INTO leg_swaps (pool_id, leg_id, reserve_leg_id, match_status, user_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, time`,
+		swap.PoolID, swap.LegID, swap.ReserveLegID, swap.MatchStatus, nullString(userID),
+	).Scan(&swap.ID, &swap.Time)
+	if err != nil {
+		return nil, errors.Wrapf(err, "recording swap of leg %s", leg.ID)
+	}
+	err = writeAudit(q, leg.PoolID, userID, auditSubstituteLeg, map[string]string{
+		"legId":          leg.ID,
+		"matchId":        leg.MatchID,
+		"matchStatus":    leg.MatchStatus,
+		"reserveLegId":   reserveID,
+		"reserveMatchId": reserveMatchID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{
+		"pool":         leg.PoolID,
+		"leg":          leg.ID,
+		"match":        leg.MatchID,
+		"matchStatus":  leg.MatchStatus,
+		"reserveLeg":   reserveID,
+		"reserveMatch": reserveMatchID,
+	}).Info("void leg replaced by reserve")
+	return swap, nil
+}
+
+// LegSwaps returns the reserve swaps of pool id, oldest first.
+func LegSwaps(q Querier, id string) ([]*LegSwap, error) {
+	rows, err := q.Query(`
+		SELECT id, pool_id, leg_id, reserve_leg_id, match_status, coalesce(user_id::text, ''), time
+		FROM leg_swaps
+		WHERE pool_id = $1
+		ORDER BY time, id`, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading leg swaps of pool %s", id)
+	}
+	defer rows.Close()
+
+	var swaps []*LegSwap
+	for rows.Next() {
+		s := &LegSwap{}
+		if err := rows.Scan(&s.ID, &s.PoolID, &s.LegID, &s.ReserveLegID, &s.MatchStatus, &s.UserID, &s.Time); err != nil {
+			return nil, errors.Wrap(err, "scanning leg swap")
+		}
+		swaps = append(swaps, s)
+	}
+	return swaps, errors.Wrap(rows.Err(), "reading leg swaps")
+}
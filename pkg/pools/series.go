@@ -0,0 +1,264 @@
+package pools
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// Series is an ordered chain of pools of one game and type. Money a settled
+// pool rolls over is carried into the next open pool of its series.
+type Series struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Game      string    `json:"game"`
+	Type      Type      `json:"type"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CarryTransfer records money rolled from one pool of a series into the next.
+type CarryTransfer struct {
+	ID         string          `json:"id"`
+	SeriesID   string          `json:"seriesId"`
+	FromPoolID string          `json:"fromPoolId"`
+	ToPoolID   string          `json:"toPoolId"`
+	Amount     decimal.Decimal `json:"amount"`
+	Currency   Currency        `json:"currency"`
+	UserID     string          `json:"userId"`
+	Time       time.Time       `json:"time"`
+}
+
+// acceptsCarryIn reports whether the carry-in of a pool in status s may
+// still change, i.e. trading has not closed yet.
+func acceptsCarryIn(s Status) bool {
+	switch s {
+	case StatusTradingClosed, StatusOfficial, StatusSettled, StatusAbandoned:
+		return false
+	}
+	return true
+}
+
+// CreateSeries starts an empty series for pools of game and poolType.
+func CreateSeries(q Querier, name, game string, poolType Type) (*Series, error) {
+	s := &Series{Name: name, Game: game, Type: poolType}
+	err := q.QueryRow(`
+		INSERT INTO pool_series (name, game, type)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`, name, game, string(poolType),
+	).Scan(&s.ID, &s.CreatedAt)
+	return s, errors.Wrap(err, "creating pool series")
+}
+
+// AddToSeries appends pool poolID to series seriesID and pulls in any carry
+// the series is holding for its next pool.
+func AddToSeries(q Querier, seriesID, poolID, userID string) error {
+	var game, poolType string
+	err := q.QueryRow(`SELECT game, type FROM pool_series WHERE id = $1 FOR UPDATE`, seriesID).Scan(&game, &poolType)
+	if err == sql.ErrNoRows {
+		return errors.Errorf("pool series %s not found", seriesID)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "reading pool series %s", seriesID)
+	}
+
+	var poolGame, poolPoolType, current sql.NullString
+	err = q.QueryRow(`SELECT game, type, series_id::text FROM pools WHERE id = $1 FOR UPDATE`, poolID).Scan(&poolGame, &poolPoolType, &current)
+	if err == sql.ErrNoRows {
+		return errors.Errorf("pool %s not found", poolID)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "reading pool %s", poolID)
+	}
+	if current.Valid {
+		return newError(CodeSeriesMismatch, poolID, "pool already belongs to series %s", current.String)
+	}
+	if poolGame.String != game || poolPoolType.String != poolType {
+		return newError(CodeSeriesMismatch, poolID, "pool is a %s %s pool, series %s is %s %s",
+			poolGame.String, poolPoolType.String, seriesID, game, poolType)
+	}
+
+	_, err = q.Exec(`
+		UPDATE pools
+		SET series_id = $1,
+		    series_sequence = (SELECT coalesce(max(series_sequence), 0) + 1 FROM pools WHERE series_id = $1)
+		WHERE id = $2`, seriesID, poolID)
+	if err != nil {
+		return errors.Wrapf(err, "adding pool %s to series %s", poolID, seriesID)
+	}
+
+	// Settled pools whose carry found no open successor roll into this one.
+	rows, err := q.Query(`
+		SELECT p.id
+		FROM pools p
+		WHERE p.series_id = $1
+		  AND p.synced_colossus_status = $2
+		  AND p.carry_over > 0
+		  AND NOT EXISTS (SELECT 1 FROM carry_transfers t WHERE t.from_pool_id = p.id)
+		ORDER BY p.series_sequence`, seriesID, string(StatusSettled))
+	if err != nil {
+		return errors.Wrapf(err, "finding pending carries of series %s", seriesID)
+	}
+	var pending []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return errors.Wrap(err, "scanning pool id")
+		}
+		pending = append(pending, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "reading pending carries")
+	}
+	for _, id := range pending {
+		if _, err := CarryForward(q, id, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CarryForward moves the carry-over of settled pool id into the carry-in of
+// the next pool of its series that is still trading or being prepared. It
+// returns nil when there is nothing to carry or no such pool exists yet; in
+// the latter case AddToSeries carries the amount once the next pool joins.
+// A pool's carry-over is transferred at most once.
+func CarryForward(q Querier, id, userID string) (*CarryTransfer, error) {
+	var (
+		seriesID    sql.NullString
+		sequence    sql.NullInt64
+		status      sql.NullString
+		currency    sql.NullString
+		carryOver   decimal.NullDecimal
+		transferred bool
+	)
+	err := q.QueryRow(`
+		SELECT series_id::text, series_sequence, synced_colossus_status, currency, carry_over,
+		       EXISTS (SELECT 1 FROM carry_transfers WHERE from_pool_id = pools.id)
+		FROM pools
+		WHERE id = $1
+		FOR UPDATE`, id,
+	).Scan(&seriesID, &sequence, &status, &currency, &carryOver, &transferred)
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("pool %s not found", id)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading pool %s", id)
+	}
+	if !seriesID.Valid || !carryOver.Valid || carryOver.Decimal.Sign() <= 0 || transferred {
+		return nil, nil
+	}
+	if Status(status.String) != StatusSettled {
+		return nil, newError(CodeNotSettleable, id, "pool is %s, only %s pools carry over", status.String, StatusSettled)
+	}
+
+	rows, err := q.Query(`
+		SELECT id, synced_colossus_status, currency
+		FROM pools
+		WHERE series_id = $1 AND series_sequence > $2
+		ORDER BY series_sequence
+		FOR UPDATE`, seriesID.String, sequence.Int64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding the successor of pool %s", id)
+	}
+	var next string
+	var nextCurrency Currency
+	for rows.Next() {
+		var candidate string
+		var candidateStatus, candidateCurrency sql.NullString
+		if err := rows.Scan(&candidate, &candidateStatus, &candidateCurrency); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "scanning pool")
+		}
+		if acceptsCarryIn(Status(candidateStatus.String)) {
+			next, nextCurrency = candidate, storedCurrency(candidateCurrency)
+			break
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading series pools")
+	}
+	if next == "" {
+		log.WithFields(log.Fields{"pool": id, "series": seriesID.String, "amount": carryOver.Decimal}).Info("carry-over waiting for the next pool of the series")
+		return nil, nil
+	}
+	if from := storedCurrency(currency); nextCurrency != from {
+		return nil, newError(CodeSeriesMismatch, id, "cannot carry %s into pool %s in %s", from, next, nextCurrency)
+	}
+
+	if _, err := q.Exec(`UPDATE pools SET carry_in = coalesce(carry_in, 0) + $2 WHERE id = $1`, next, carryOver.Decimal); err != nil {
+		return nil, errors.Wrapf(err, "adding carry-in to pool %s", next)
+	}
+	t := &CarryTransfer{
+		SeriesID:   seriesID.String,
+		FromPoolID: id,
+		ToPoolID:   next,
+		Amount:     carryOver.Decimal,
+		Currency:   storedCurrency(currency),
+		UserID:     userID,
+	}
+	err = q.QueryRow(`
+		INSERT INTO carry_transfers (series_id, from_pool_id, to_pool_id, amount, currency, user_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, time`,
+		t.SeriesID, t.FromPoolID, t.ToPoolID, t.Amount, t.Currency, nullString(userID),
+	).Scan(&t.ID, &t.Time)
+	if err != nil {
+		return nil, errors.Wrapf(err, "recording carry transfer from pool %s", id)
+	}
+
+	log.WithFields(log.Fields{
+		"series": t.SeriesID,
+		"from":   t.FromPoolID,
+		"to":     t.ToPoolID,
+		"amount": t.Amount,
+	}).Info("carried over to next pool")
+	return t, nil
+}
+
+// RecordPayout stores the carry-over of the final payout of pool id, moves
+// the pool from OFFICIAL to SETTLED and carries the amount forward.
+func RecordPayout(q Querier, id string, payout *Payout, userID string) (*CarryTransfer, error) {
+	status, err := lockStatus(q, id)
+	if err != nil {
+		return nil, err
+	}
+	if status == StatusSettled {
+		return nil, newError(CodeNotSettleable, id, "payout has already been recorded")
+	}
+	if _, err := TransitionStatus(q, id, StatusSettled, userID, "payout recorded"); err != nil {
+		return nil, err
+	}
+	if _, err := q.Exec(`UPDATE pools SET carry_over = $2 WHERE id = $1`, id, payout.CarryOver); err != nil {
+		return nil, errors.Wrapf(err, "recording carry-over of pool %s", id)
+	}
+	return CarryForward(q, id, userID)
+}
+
+// CarryTransfers returns the transfers of series seriesID, oldest first.
+func CarryTransfers(q Querier, seriesID string) ([]*CarryTransfer, error) {
+	rows, err := q.Query(`
+		SELECT id, series_id, from_pool_id, to_pool_id, amount, currency, coalesce(user_id::text, ''), time
+		FROM carry_transfers
+		WHERE series_id = $1
+		ORDER BY time, id`, seriesID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading carry transfers of series %s", seriesID)
+	}
+	defer rows.Close()
+
+	var transfers []*CarryTransfer
+	for rows.Next() {
+		t := &CarryTransfer{}
+		if err := rows.Scan(&t.ID, &t.SeriesID, &t.FromPoolID, &t.ToPoolID, &t.Amount, &t.Currency, &t.UserID, &t.Time); err != nil {
+			return nil, errors.Wrap(err, "scanning carry transfer")
+		}
+		transfers = append(transfers, t)
+	}
+	return transfers, errors.Wrap(rows.Err(), "reading carry transfers")
+}
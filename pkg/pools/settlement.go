@@ -0,0 +1,348 @@
+package pools
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+)
+
+// Type mirrors the PoolType GraphQL enum.
+type Type string
+
+const (
+	TypeH2H       Type = "H2H"
+	TypeOverUnder Type = "OVER_UNDER"
+	TypeFantasy   Type = "FANTASY"
+)
+
+// Outcome mirrors the LegOutcome GraphQL enum.
+type Outcome string
+
+const (
+	// OutcomeWinner means the competitor in WinnerID won the match.
+	OutcomeWinner Outcome = "WINNER"
+	// OutcomeDraw means the top scores were level.
+	OutcomeDraw Outcome = "DRAW"
+	// OutcomeVoid means the match ended without a result.
+	OutcomeVoid Outcome = "VOID"
+	// OutcomeOver means the over/under total was above the threshold.
+	OutcomeOver Outcome = "OVER"
+	// OutcomeUnder means the over/under total was below the threshold.
+	OutcomeUnder Outcome = "UNDER"
+	// OutcomePush means the total equalled a whole-number threshold.
+	OutcomePush Outcome = "PUSH"
+	// OutcomeScored means the fantasy player's points are in Total.
+	OutcomeScored Outcome = "SCORED"
+)
+
+// UnmarshalGQL implements the gqlgen Unmarshaler for the LegOutcome enum.
+func (o *Outcome) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("LegOutcome must be a string")
+	}
+	*o = Outcome(str)
+	return nil
+}
+
+// MarshalGQL implements the gqlgen Marshaler for the LegOutcome enum.
+func (o Outcome) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(o)))
+}
+
+// Match internal statuses after which a match will not change any more.
+// Only FINISHED and CLOSED matches have a result; the void statuses void
+// their legs.
+var (
+	resultMatchStatuses = map[string]bool{
+		"FINISHED": true,
+		"CLOSED":   true,
+	}
+	// voidMatchStatuses is the one list of statuses that void a leg. The
+	// settlement, reserve substitution, trading closer and validation all
+	// use it.
+	voidMatchStatuses = []string{"CANCELLED", "ABANDONED", "POSTPONED", "INTERRUPTED"}
+)
+
+func isFinalMatchStatus(status string) bool {
+	return resultMatchStatuses[status] || containsString(voidMatchStatuses, status)
+}
+
+func finalMatchStatusList() interface{} {
+	statuses := append([]string(nil), voidMatchStatuses...)
+	for status := range resultMatchStatuses {
+		statuses = append(statuses, status)
+	}
+	return pq.Array(statuses)
+}
+
+// TeamScore is one entry of the matches.team_scores and team_ou_scores JSON.
+type TeamScore struct {
+	TeamID string `json:"teamId"`
+	Score  int    `json:"score"`
+}
+
+// LegResult is the settled outcome of a leg. Total holds the over/under
+// total or the fantasy points; Threshold is only set for over/under legs.
+type LegResult struct {
+	LegID     string           `json:"legId"`
+	Outcome   Outcome          `json:"outcome"`
+	WinnerID  string           `json:"winnerId"`
+	Total     *decimal.Decimal `json:"total"`
+	Threshold *decimal.Decimal `json:"threshold"`
+	SettledAt time.Time        `json:"settledAt"`
+}
+
+// settlementLeg is a leg with the match data needed to resolve it.
+type settlementLeg struct {
+	ID           string
+	MatchID      string
+	MatchStatus  string
+	Threshold    decimal.NullDecimal
+	TeamScores   []TeamScore
+	TeamOUScores []TeamScore
+
+	PlayerID         string
+	PlayerExternalID string
+	Statistics       []byte
+}
+
+func loadSettlementLegs(q Querier, poolID string) ([]*settlementLeg, error) {
+	rows, err := q.Query(`
+		SELECT l.id, l.match_id, coalesce(m.internal_status, ''), l.threshold,
+		       coalesce(m.team_scores, '[]'), coalesce(m.team_ou_scores, '[]'),
+		       coalesce(l.player_id::text, ''), coalesce(p.external_id, ''), coalesce(m.statistics, '{}')
+		FROM legs l
+		JOIN matches m ON m.id = l.match_id
+		LEFT JOIN players p ON p.id = l.player_id
+		WHERE l.pool_id = $1 AND l.reserve_position IS NULL AND l.replaced_by IS NULL
+		ORDER BY m.start_time, l.id`, poolID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading legs of pool %s", poolID)
+	}
+	defer rows.Close()
+
+	var legs []*settlementLeg
+	for rows.Next() {
+		leg := &settlementLeg{}
+		var scores, ouScores []byte
+		if err := rows.Scan(&leg.ID, &leg.MatchID, &leg.MatchStatus, &leg.Threshold, &scores, &ouScores,
+			&leg.PlayerID, &leg.PlayerExternalID, &leg.Statistics); err != nil {
+			return nil, errors.Wrap(err, "scanning leg")
+		}
+		if err := json.Unmarshal(scores, &leg.TeamScores); err != nil {
+			return nil, errors.Wrapf(err, "decoding team scores of match %s", leg.MatchID)
+		}
+		if err := json.Unmarshal(ouScores, &leg.TeamOUScores); err != nil {
+			return nil, errors.Wrapf(err, "decoding over/under scores of match %s", leg.MatchID)
+		}
+		legs = append(legs, leg)
+	}
+	return legs, errors.Wrap(rows.Err(), "reading legs")
+}
+
+// resolveH2H picks the competitor with the highest score.
+func resolveH2H(poolID string, leg *settlementLeg) (*LegResult, error) {
+	result := &LegResult{LegID: leg.ID}
+	if !resultMatchStatuses[leg.MatchStatus] {
+		result.Outcome = OutcomeVoid
+		return result, nil
+	}
+	if len(leg.TeamScores) < 2 {
+		return nil, newError(CodeMissingMatchData, poolID, "match %s has %d team scores, need 2", leg.MatchID, len(leg.TeamScores))
+	}
+
+	best := leg.TeamScores[0]
+	level := false
+	for _, score := range leg.TeamScores[1:] {
+		switch {
+		case score.Score > best.Score:
+			best, level = score, false
+		case score.Score == best.Score:
+			level = true
+		}
+	}
+	if level {
+		result.Outcome = OutcomeDraw
+		return result, nil
+	}
+	result.Outcome = OutcomeWinner
+	result.WinnerID = best.TeamID
+	return result, nil
+}
+
+// resolveLeg settles one leg according to the pool type. rules are the
+// fantasy scoring rules of the pool's game.
+func resolveLeg(poolID string, poolType Type, rules []*ScoringRule, leg *settlementLeg) (*LegResult, error) {
+	switch poolType {
+	case TypeH2H:
+		return resolveH2H(poolID, leg)
+	case TypeOverUnder:
+		return resolveOverUnder(poolID, leg)
+	case TypeFantasy:
+		return resolveFantasy(poolID, rules, leg)
+	default:
+		return nil, newError(CodeNotSettleable, poolID, "settlement of %s pools is not supported", poolType)
+	}
+}
+
+func saveLegResult(q Querier, result *LegResult) error {
+	err := q.QueryRow(`
+		INSERT INTO leg_results (leg_id, outcome, winner_id, total, threshold, settled_at)
+		VALUES ($1, $2, $3, $4, $5, now())
+		ON CONFLICT (leg_id) DO UPDATE
+		SET outcome = EXCLUDED.outcome, winner_id = EXCLUDED.winner_id,
+		    total = EXCLUDED.total, threshold = EXCLUDED.threshold, settled_at = EXCLUDED.settled_at
+		RETURNING settled_at`,
+		result.LegID, string(result.Outcome), nullString(result.WinnerID),
+		nullDecimal(result.Total), nullDecimal(result.Threshold),
+	).Scan(&result.SettledAt)
+	return errors.Wrapf(err, "saving result of leg %s", result.LegID)
+}
+
+// SettlePool resolves every leg of pool id once all its matches are final
+// and moves the pool from TRADING_CLOSED to OFFICIAL. Void legs are handled
+// by the void rule of the pool type, which is recorded on the pool; a pool
+// with too many void legs is abandoned instead. Re-running it on an
+// OFFICIAL pool recomputes the results, e.g. after a score correction.
+func SettlePool(q Querier, id, userID string) ([]*LegResult, error) {
+	var poolType, status, game sql.NullString
+	err := q.QueryRow(`SELECT type, synced_colossus_status, game FROM pools WHERE id = $1 FOR UPDATE`, id).Scan(&poolType, &status, &game)
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("pool %s not found", id)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading pool %s", id)
+	}
+	current := Status(status.String)
+	if current != StatusTradingClosed && current != StatusOfficial {
+		return nil, newError(CodeNotSettleable, id, "pool is %s, settlement needs %s or %s", current, StatusTradingClosed, StatusOfficial)
+	}
+
+	legs, err := loadSettlementLegs(q, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(legs) == 0 {
+		return nil, newError(CodeNotSettleable, id, "pool has no legs")
+	}
+	var pending []string
+	for _, leg := range legs {
+		if !isFinalMatchStatus(leg.MatchStatus) {
+			pending = append(pending, leg.MatchID)
+		}
+	}
+	if len(pending) > 0 {
+		e := newError(CodeMatchesNotFinal, id, "%d of %d matches are not final", len(pending), len(legs))
+		e.Details = map[string]interface{}{"matchIds": pending}
+		return nil, e
+	}
+
+	var rules []*ScoringRule
+	if Type(poolType.String) == TypeFantasy {
+		if rules, err = ScoringRules(q, game.String); err != nil {
+			return nil, err
+		}
+		if len(rules) == 0 {
+			return nil, newError(CodeNotSettleable, id, "no fantasy scoring rules for %s", game.String)
+		}
+	}
+
+	results := make([]*LegResult, 0, len(legs))
+	for _, leg := range legs {
+		result, err := resolveLeg(id, Type(poolType.String), rules, leg)
+		if err != nil {
+			return nil, err
+		}
+		if err := saveLegResult(q, result); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	voidRule, err := LoadVoidRule(q, Type(poolType.String))
+	if err != nil {
+		return nil, err
+	}
+	voids := countVoid(results)
+	applied := voidRule.apply(voids)
+	if _, err := q.Exec(`UPDATE pools SET void_rule = $2, void_legs = $3 WHERE id = $1`, id, nullString(string(applied)), voids); err != nil {
+		return nil, errors.Wrapf(err, "recording void rule of pool %s", id)
+	}
+
+	if applied == VoidAbandon {
+		reason := fmt.Sprintf("%d legs void, %s pools allow %d", voids, poolType.String, *voidRule.AbandonAbove)
+		if _, err := TransitionStatus(q, id, StatusAbandoned, userID, reason); err != nil {
+			return nil, err
+		}
+		log.WithFields(log.Fields{"pool": id, "voidLegs": voids, "user": userID}).Warn("pool abandoned for void legs")
+		return results, nil
+	}
+	if current == StatusTradingClosed {
+		if _, err := TransitionStatus(q, id, StatusOfficial, userID, "all legs settled"); err != nil {
+			return nil, err
+		}
+	}
+	log.WithFields(log.Fields{"pool": id, "legs": len(results), "voidLegs": voids, "voidRule": applied, "user": userID}).Info("pool settled")
+	return results, nil
+}
+
+// SettlePool settles pool id in its own transaction.
+func (s *Store) SettlePool(id, userID string) ([]*LegResult, error) {
+	var results []*LegResult
+	err := s.InTx(func(q Querier) error {
+		var err error
+		results, err = SettlePool(q, id, userID)
+		return err
+	})
+	return results, err
+}
+
+// SettleMatchPools settles every TRADING_CLOSED pool with a leg on match
+// matchID whose matches have all become final. Call it after a match
+// update; pools that fail to settle are logged and left for a manual
+// settlePool.
+func (s *Store) SettleMatchPools(matchID string) error {
+	rows, err := s.db.Query(`
+		SELECT DISTINCT p.id
+		FROM pools p
+		JOIN legs l ON l.pool_id = p.id
+		WHERE l.match_id = $1 AND l.reserve_position IS NULL AND l.replaced_by IS NULL
+		  AND p.synced_colossus_status = $2
+		  AND NOT EXISTS (
+		    SELECT 1 FROM legs pl JOIN matches m ON m.id = pl.match_id
+		    WHERE pl.pool_id = p.id AND pl.reserve_position IS NULL AND pl.replaced_by IS NULL
+		      AND coalesce(m.internal_status, '') <> ALL ($3)
+		  )`, matchID, string(StatusTradingClosed), finalMatchStatusList())
+	if err != nil {
+		return errors.Wrapf(err, "finding pools of match %s", matchID)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return errors.Wrap(err, "scanning pool id")
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "reading pool ids")
+	}
+
+	for _, id := range ids {
+		if _, err := s.SettlePool(id, ""); err != nil {
+			log.WithError(err).WithFields(log.Fields{"pool": id, "match": matchID}).Error("automatic settlement failed")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package pools
+
+import "testing"
+
+func TestResolveH2H(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		scores  []TeamScore
+		outcome Outcome
+		winner  string
+		code    string
+	}{
+		{
+			name:    "home wins",
+			status:  "FINISHED",
+			scores:  []TeamScore{{TeamID: "home", Score: 2}, {TeamID: "away", Score: 1}},
+			outcome: OutcomeWinner,
+			winner:  "home",
+		},
+		{
+			name:    "away wins after closing",
+			status:  "CLOSED",
+			scores:  []TeamScore{{TeamID: "home", Score: 0}, {TeamID: "away", Score: 3}},
+			outcome: OutcomeWinner,
+			winner:  "away",
+		},
+		{
+			name:    "draw",
+			status:  "FINISHED",
+			scores:  []TeamScore{{TeamID: "home", Score: 1}, {TeamID: "away", Score: 1}},
+			outcome: OutcomeDraw,
+		},
+		{
+			name:    "level top scores of three",
+			status:  "FINISHED",
+			scores:  []TeamScore{{TeamID: "a", Score: 1}, {TeamID: "b", Score: 4}, {TeamID: "c", Score: 4}},
+			outcome: OutcomeDraw,
+		},
+		{
+			name:    "later leader breaks an earlier tie",
+			status:  "FINISHED",
+			scores:  []TeamScore{{TeamID: "a", Score: 1}, {TeamID: "b", Score: 1}, {TeamID: "c", Score: 2}},
+			outcome: OutcomeWinner,
+			winner:  "c",
+		},
+		{name: "cancelled", status: "CANCELLED", outcome: OutcomeVoid},
+		{name: "abandoned", status: "ABANDONED", outcome: OutcomeVoid},
+		{name: "postponed", status: "POSTPONED", outcome: OutcomeVoid},
+		{name: "interrupted", status: "INTERRUPTED", outcome: OutcomeVoid},
+		{
+			name:   "missing scores",
+			status: "FINISHED",
+			scores: []TeamScore{{TeamID: "home", Score: 1}},
+			code:   CodeMissingMatchData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leg := &settlementLeg{ID: "leg", MatchID: "match", MatchStatus: tt.status, TeamScores: tt.scores}
+			result, err := resolveH2H("pool", leg)
+			if tt.code != "" {
+				if e, ok := err.(*Error); !ok || e.Code != tt.code {
+					t.Fatalf("got error %v, want code %s", err, tt.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Outcome != tt.outcome || result.WinnerID != tt.winner {
+				t.Errorf("got %s/%q, want %s/%q", result.Outcome, result.WinnerID, tt.outcome, tt.winner)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Package pools holds the pool lifecycle rules shared by the GraphQL
+// resolvers and the background jobs.
+package pools
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Status mirrors the PoolStatus GraphQL enum and the pools.synced_colossus_status column.
+type Status string
+
+const (
+	StatusNotReady      Status = "NOT_READY"
+	StatusNeedsApproval Status = "NEEDS_APPROVAL"
+	StatusSyncError     Status = "SYNC_ERROR"
+	StatusApproved      Status = "APPROVED"
+	StatusCreated       Status = "CREATED"
+	StatusVisible       Status = "VISIBLE"
+	StatusTradingOpen   Status = "TRADING_OPEN"
+	StatusTradingClosed Status = "TRADING_CLOSED"
+	StatusOfficial      Status = "OFFICIAL"
+	StatusSettled       Status = "SETTLED"
+	StatusAbandoned     Status = "ABANDONED"
+)
+
+// transitions lists the statuses each status may move to. SETTLED and
+// ABANDONED are final. A pool in SYNC_ERROR may besides be abandoned only
+// return to the status it failed in, which its history records, see
+// syncErrorOrigin.
+var transitions = map[Status][]Status{
+	StatusNotReady:      {StatusNeedsApproval, StatusAbandoned},
+	StatusNeedsApproval: {StatusNotReady, StatusApproved, StatusAbandoned},
+	StatusApproved:      {StatusNeedsApproval, StatusCreated, StatusSyncError, StatusAbandoned},
+	StatusSyncError:     {StatusAbandoned},
+	StatusCreated:       {StatusVisible, StatusSyncError, StatusAbandoned},
+	StatusVisible:       {StatusTradingOpen, StatusSyncError, StatusAbandoned},
+	StatusTradingOpen:   {StatusTradingClosed, StatusSyncError, StatusAbandoned},
+	StatusTradingClosed: {StatusTradingOpen, StatusOfficial, StatusSyncError, StatusAbandoned},
+	StatusOfficial:      {StatusSettled, StatusSyncError, StatusAbandoned},
+	StatusSettled:       {},
+	StatusAbandoned:     {},
+}
+
+// IsValid reports whether s is one of the PoolStatus values.
+func (s Status) IsValid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// IsFinal reports whether no transition leaves s.
+func (s Status) IsFinal() bool {
+	return s.IsValid() && len(transitions[s]) == 0
+}
+
+// CanTransition reports whether a pool in status from may move to status to.
+// It does not know where a pool in SYNC_ERROR came from and so only allows
+// abandoning it.
+func CanTransition(from, to Status) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStatuses returns the statuses reachable from s, sorted, leaving out
+// the return from SYNC_ERROR.
+func NextStatuses(s Status) []Status {
+	return allowedStatuses(s, "")
+}
+
+// allowedStatuses returns the statuses reachable from status from, sorted.
+// origin is the status a pool in SYNC_ERROR failed in, or "" when unknown.
+func allowedStatuses(from, origin Status) []Status {
+	next := append([]Status(nil), transitions[from]...)
+	if from == StatusSyncError && origin.IsValid() && origin != StatusSyncError {
+		next = append(next, origin)
+	}
+	sort.Slice(next, func(i, j int) bool { return next[i] < next[j] })
+	return next
+}
+
+// TransitionError is returned when a status change is not allowed. gqlgen
+// copies Extensions into the GraphQL error so clients can branch on the code.
+type TransitionError struct {
+	Code   string
+	PoolID string
+	From   Status
+	To     Status
+	// Origin is the status a pool in SYNC_ERROR failed in, if known.
+	Origin Status
+}
+
+func (e *TransitionError) Error() string {
+	if e.Code == CodeInvalidStatus {
+		return fmt.Sprintf("pool %s: unknown status %q", e.PoolID, e.To)
+	}
+	return fmt.Sprintf("pool %s: cannot move from %s to %s", e.PoolID, e.From, e.To)
+}
+
+// Extensions implements gqlgen's graphql.ExtendedError.
+func (e *TransitionError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":    e.Code,
+		"poolId":  e.PoolID,
+		"from":    e.From,
+		"to":      e.To,
+		"allowed": allowedStatuses(e.From, e.Origin),
+	}
+}
+
+// checkTransition validates a status change of pool id. origin is the
+// status the pool failed in when from is SYNC_ERROR.
+func checkTransition(id string, from, to, origin Status) error {
+	if !to.IsValid() {
+		return &TransitionError{Code: CodeInvalidStatus, PoolID: id, From: from, To: to, Origin: origin}
+	}
+	for _, next := range allowedStatuses(from, origin) {
+		if next == to {
+			return nil
+		}
+	}
+	return &TransitionError{Code: CodeIllegalTransition, PoolID: id, From: from, To: to, Origin: origin}
+}
+
+// UnmarshalGQL implements the gqlgen Unmarshaler for the PoolStatus enum.
+func (s *Status) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("PoolStatus must be a string")
+	}
+	*s = Status(str)
+	if !s.IsValid() {
+		return fmt.Errorf("%s is not a valid PoolStatus", str)
+	}
+	return nil
+}
+
+// MarshalGQL implements the gqlgen Marshaler for the PoolStatus enum.
+func (s Status) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(s)))
+}
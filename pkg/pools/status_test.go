@@ -0,0 +1,88 @@
+package pools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusNotReady, StatusNeedsApproval, true},
+		{StatusNotReady, StatusApproved, false},
+		{StatusNeedsApproval, StatusApproved, true},
+		{StatusNeedsApproval, StatusNotReady, true},
+		{StatusApproved, StatusCreated, true},
+		{StatusCreated, StatusTradingOpen, false},
+		{StatusTradingOpen, StatusTradingClosed, true},
+		{StatusTradingClosed, StatusTradingOpen, true},
+		{StatusTradingClosed, StatusOfficial, true},
+		{StatusOfficial, StatusSettled, true},
+		{StatusOfficial, StatusSyncError, true},
+		{StatusSyncError, StatusAbandoned, true},
+		{StatusSyncError, StatusTradingOpen, false},
+		{StatusSyncError, StatusApproved, false},
+		{StatusSettled, StatusOfficial, false},
+		{StatusAbandoned, StatusNotReady, false},
+		{Status("BOGUS"), StatusAbandoned, false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		name             string
+		from, to, origin Status
+		code             string
+	}{
+		{name: "allowed", from: StatusVisible, to: StatusTradingOpen},
+		{name: "illegal", from: StatusVisible, to: StatusSettled, code: CodeIllegalTransition},
+		{name: "unknown status", from: StatusVisible, to: Status("LIVE"), code: CodeInvalidStatus},
+		{name: "sync error back to origin", from: StatusSyncError, to: StatusTradingOpen, origin: StatusTradingOpen},
+		{name: "sync error elsewhere", from: StatusSyncError, to: StatusOfficial, origin: StatusTradingOpen, code: CodeIllegalTransition},
+		{name: "sync error abandoned", from: StatusSyncError, to: StatusAbandoned, origin: StatusCreated},
+		{name: "sync error without origin", from: StatusSyncError, to: StatusApproved, code: CodeIllegalTransition},
+		{name: "origin only applies to sync error", from: StatusVisible, to: StatusApproved, origin: StatusApproved, code: CodeIllegalTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTransition("pool", tt.from, tt.to, tt.origin)
+			if tt.code == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+			te, ok := err.(*TransitionError)
+			if !ok {
+				t.Fatalf("got %v, want a *TransitionError", err)
+			}
+			if te.Code != tt.code {
+				t.Errorf("got code %s, want %s", te.Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestTransitionErrorAllowed(t *testing.T) {
+	err := checkTransition("pool", StatusSyncError, StatusOfficial, StatusVisible).(*TransitionError)
+	want := []Status{StatusAbandoned, StatusVisible}
+	if got := err.Extensions()["allowed"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("allowed = %v, want %v", got, want)
+	}
+}
+
+func TestStatusIsFinal(t *testing.T) {
+	for s := range transitions {
+		want := s == StatusSettled || s == StatusAbandoned
+		if got := s.IsFinal(); got != want {
+			t.Errorf("%s.IsFinal() = %v, want %v", s, got, want)
+		}
+	}
+}
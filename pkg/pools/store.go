@@ -0,0 +1,51 @@
+package pools
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Querier is satisfied by both *sql.DB and *sql.Tx.
+type Querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Store runs pool operations against the betting-feed database.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore returns a Store using db.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// InTx runs fn in a transaction, committing when it returns nil.
+func (s *Store) InTx(fn func(q Querier) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "beginning transaction")
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "committing transaction")
+}
+
+// nullString maps "" to NULL, e.g. for the system user.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullDecimal maps nil to NULL.
+func nullDecimal(d *decimal.Decimal) decimal.NullDecimal {
+	if d == nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NullDecimal{Decimal: *d, Valid: true}
+}
@@ -0,0 +1,154 @@
+package pools
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ThresholdRule mirrors the ThresholdRule GraphQL enum and records why a
+// leg has its over/under threshold.
+type ThresholdRule string
+
+const (
+	// ThresholdEven is the even threshold, used when no team reaches the
+	// game's favored cut-off or the match has no win probabilities.
+	ThresholdEven ThresholdRule = "EVEN"
+	// ThresholdFavored is the favored threshold, used when a team's win
+	// probability reaches the cut-off.
+	ThresholdFavored ThresholdRule = "FAVORED"
+	// ThresholdManual is a threshold entered by a trader.
+	ThresholdManual ThresholdRule = "MANUAL"
+)
+
+// UnmarshalGQL implements the gqlgen Unmarshaler for the ThresholdRule enum.
+func (r *ThresholdRule) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("ThresholdRule must be a string")
+	}
+	*r = ThresholdRule(str)
+	return nil
+}
+
+// MarshalGQL implements the gqlgen Marshaler for the ThresholdRule enum.
+func (r ThresholdRule) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(r)))
+}
+
+// TeamWinProbability is one entry of the matches.team_win_probabilities JSON.
+type TeamWinProbability struct {
+	TeamID      string          `json:"teamId"`
+	Probability decimal.Decimal `json:"probability"`
+}
+
+// ThresholdChoice is the threshold selected for an over/under leg.
+type ThresholdChoice struct {
+	Threshold decimal.Decimal
+	Rule      ThresholdRule
+	// Probability is the highest team win probability, if the match has any.
+	Probability *decimal.Decimal
+}
+
+// thresholdTable holds the over/under defaults of a game by match format
+// and the game's favored cut-off.
+type thresholdTable struct {
+	even    map[string]decimal.Decimal
+	favored map[string]decimal.Decimal
+	cutoff  decimal.NullDecimal
+}
+
+func loadThresholdTable(q Querier, game string) (*thresholdTable, error) {
+	t := &thresholdTable{even: map[string]decimal.Decimal{}, favored: map[string]decimal.Decimal{}}
+	rows, err := q.Query(`SELECT match_format, even_threshold, favored_threshold FROM over_under_defaults WHERE game = $1`, game)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading over/under defaults of %s", game)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var format string
+		var even, favored decimal.Decimal
+		if err := rows.Scan(&format, &even, &favored); err != nil {
+			return nil, errors.Wrap(err, "scanning over/under default")
+		}
+		t.even[format] = even
+		t.favored[format] = favored
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading over/under defaults")
+	}
+
+	err = q.QueryRow(`SELECT favored_probability FROM over_under_cutoffs WHERE game = $1`, game).Scan(&t.cutoff)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "reading over/under cut-off of %s", game)
+	}
+	return t, nil
+}
+
+// choose picks the favored threshold when the strongest team's win
+// probability reaches the cut-off and the even threshold otherwise. A game
+// without a cut-off always uses the even threshold. It reports false when
+// there is no default for the format.
+func (t *thresholdTable) choose(format string, probabilities []TeamWinProbability) (*ThresholdChoice, bool) {
+	even, ok := t.even[format]
+	if !ok {
+		return nil, false
+	}
+	choice := &ThresholdChoice{Threshold: even, Rule: ThresholdEven}
+	for _, p := range probabilities {
+		if choice.Probability == nil || p.Probability.GreaterThan(*choice.Probability) {
+			probability := p.Probability
+			choice.Probability = &probability
+		}
+	}
+	if t.cutoff.Valid && choice.Probability != nil && choice.Probability.Cmp(t.cutoff.Decimal) >= 0 {
+		choice.Threshold = t.favored[format]
+		choice.Rule = ThresholdFavored
+	}
+	return choice, true
+}
+
+func decodeWinProbabilities(data []byte) ([]TeamWinProbability, error) {
+	var probabilities []TeamWinProbability
+	if len(data) == 0 {
+		return nil, nil
+	}
+	err := json.Unmarshal(data, &probabilities)
+	return probabilities, err
+}
+
+// ChooseThreshold selects the over/under threshold for a leg on match
+// matchID, e.g. when a leg is created without one.
+func ChooseThreshold(q Querier, matchID string) (*ThresholdChoice, error) {
+	var game, format string
+	var data []byte
+	err := q.QueryRow(`
+		SELECT coalesce(e.game, ''), coalesce(m.format, 'UNKNOWN'), coalesce(m.team_win_probabilities, '[]')
+		FROM matches m
+		JOIN events e ON e.id = m.event_id
+		WHERE m.id = $1`, matchID).Scan(&game, &format, &data)
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("match %s not found", matchID)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading match %s", matchID)
+	}
+	probabilities, err := decodeWinProbabilities(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding win probabilities of match %s", matchID)
+	}
+	table, err := loadThresholdTable(q, game)
+	if err != nil {
+		return nil, err
+	}
+	choice, ok := table.choose(format, probabilities)
+	if !ok {
+		return nil, errors.Errorf("no over/under default for %s %s", game, format)
+	}
+	return choice, nil
+}
@@ -0,0 +1,61 @@
+package pools
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestThresholdTableChoose(t *testing.T) {
+	table := &thresholdTable{
+		even:    map[string]decimal.Decimal{"BO1": mustDecimal("26.5"), "BO3": mustDecimal("2.5")},
+		favored: map[string]decimal.Decimal{"BO1": mustDecimal("24.5"), "BO3": mustDecimal("2")},
+		cutoff:  decimal.NullDecimal{Decimal: mustDecimal("0.7"), Valid: true},
+	}
+	noCutoff := &thresholdTable{even: table.even, favored: table.favored}
+	probs := func(values ...string) []TeamWinProbability {
+		var p []TeamWinProbability
+		for i, v := range values {
+			p = append(p, TeamWinProbability{TeamID: string(rune('a' + i)), Probability: mustDecimal(v)})
+		}
+		return p
+	}
+
+	tests := []struct {
+		name          string
+		table         *thresholdTable
+		format        string
+		probabilities []TeamWinProbability
+		found         bool
+		threshold     string
+		rule          ThresholdRule
+		probability   string
+	}{
+		{name: "balanced match", table: table, format: "BO1", probabilities: probs("0.55", "0.45"), found: true, threshold: "26.5", rule: ThresholdEven, probability: "0.55"},
+		{name: "favorite at cut-off", table: table, format: "BO1", probabilities: probs("0.3", "0.7"), found: true, threshold: "24.5", rule: ThresholdFavored, probability: "0.7"},
+		{name: "favorite above cut-off", table: table, format: "BO3", probabilities: probs("0.85", "0.15"), found: true, threshold: "2", rule: ThresholdFavored, probability: "0.85"},
+		{name: "no probabilities", table: table, format: "BO3", found: true, threshold: "2.5", rule: ThresholdEven},
+		{name: "no cut-off", table: noCutoff, format: "BO1", probabilities: probs("0.9", "0.1"), found: true, threshold: "26.5", rule: ThresholdEven, probability: "0.9"},
+		{name: "unknown format", table: table, format: "BO5", probabilities: probs("0.9", "0.1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			choice, ok := tt.table.choose(tt.format, tt.probabilities)
+			if ok != tt.found {
+				t.Fatalf("got found %v, want %v", ok, tt.found)
+			}
+			if !ok {
+				return
+			}
+			if !choice.Threshold.Equal(mustDecimal(tt.threshold)) || choice.Rule != tt.rule {
+				t.Errorf("got %s %s, want %s %s", choice.Threshold, choice.Rule, tt.threshold, tt.rule)
+			}
+			switch {
+			case tt.probability == "" && choice.Probability != nil:
+				t.Errorf("got probability %s, want none", choice.Probability)
+			case tt.probability != "" && (choice.Probability == nil || !choice.Probability.Equal(mustDecimal(tt.probability))):
+				t.Errorf("got probability %v, want %s", choice.Probability, tt.probability)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package pools
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Validation issue codes.
+const (
+	IssueUnitBounds       = "UNIT_BOUNDS"
+	IssueUnitValue        = "UNIT_VALUE"
+	IssueNoLegs           = "NO_LEGS"
+	IssueDuplicateMatch   = "DUPLICATE_MATCH"
+	IssueInactiveMatch    = "INACTIVE_MATCH"
+	IssueInactiveEvent    = "INACTIVE_EVENT"
+	IssueGameMismatch     = "GAME_MISMATCH"
+	IssueMatchStarted     = "MATCH_STARTED"
+	IssueVoidedMatch      = "VOIDED_MATCH"
+	IssueLateLeg          = "LEG_STARTS_AFTER_TRADING_CLOSE"
+	IssueMissingThreshold = "MISSING_THRESHOLD"
+	IssueMissingPlayer    = "MISSING_PLAYER"
+	IssueLegCount         = "LEG_COUNT"
+)
+
+// ValidationConfig tunes the pool validation. Parse it with
+// github.com/caarlos0/env.
+type ValidationConfig struct {
+	// TradingCloseOffset matches the trading closer's offset.
+	TradingCloseOffset time.Duration `env:"POOL_TRADING_CLOSE_OFFSET" envDefault:"5m"`
+	// MaxLegSpread is how long after trading closes a leg may start before
+	// it is reported.
+	MaxLegSpread time.Duration `env:"POOL_MAX_LEG_SPREAD" envDefault:"48h"`
+}
+
+// ValidationIssue is one finding of ValidatePool. LegID, MatchID and Field
+// are set when the issue concerns them.
+type ValidationIssue struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+	LegID   string `json:"legId,omitempty"`
+	MatchID string `json:"matchId,omitempty"`
+}
+
+// ValidationReport lists what stands in the way of approving a pool.
+// Errors block approval, warnings do not.
+type ValidationReport struct {
+	PoolID   string             `json:"poolId"`
+	Errors   []*ValidationIssue `json:"errors"`
+	Warnings []*ValidationIssue `json:"warnings"`
+}
+
+// Valid reports whether the pool has no errors.
+func (r *ValidationReport) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+func (r *ValidationReport) errorf(issue ValidationIssue, format string, args ...interface{}) {
+	issue.Message = fmt.Sprintf(format, args...)
+	r.Errors = append(r.Errors, &issue)
+}
+
+func (r *ValidationReport) warnf(issue ValidationIssue, format string, args ...interface{}) {
+	issue.Message = fmt.Sprintf(format, args...)
+	r.Warnings = append(r.Warnings, &issue)
+}
+
+// validationLeg is a primary leg with what validation checks about it.
+type validationLeg struct {
+	ID          string
+	MatchID     string
+	MatchActive bool
+	EventActive bool
+	Game        string
+	MatchStatus string
+	StartTime   time.Time
+	Threshold   decimal.NullDecimal
+	PlayerID    sql.NullString
+}
+
+// ValidatePool checks pool id for mistakes the pool editor lets through.
+func ValidatePool(q Querier, id string, config ValidationConfig) (*ValidationReport, error) {
+	var poolType, game sql.NullString
+	err := q.QueryRow(`SELECT type, game FROM pools WHERE id = $1`, id).Scan(&poolType, &game)
+	if err == sql.ErrNoRows {
+		return nil, errors.Errorf("pool %s not found", id)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading pool %s", id)
+	}
+	money, err := LoadMoneySettings(q, id)
+	if err != nil {
+		return nil, err
+	}
+	rounding, err := LoadRounding(q, money.Currency)
+	if err != nil {
+		return nil, err
+	}
+	legs, err := loadValidationLegs(q, id)
+	if err != nil {
+		return nil, err
+	}
+	legCounts, err := defaultLegCounts(q, game.String, poolType.String)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ValidationReport{PoolID: id, Errors: []*ValidationIssue{}, Warnings: []*ValidationIssue{}}
+	validateMoney(r, money, rounding)
+	validateLegs(r, Type(poolType.String), game.String, legs, legCounts, time.Now(), config)
+	return r, nil
+}
+
+func validateMoney(r *ValidationReport, m *MoneySettings, rounding *CurrencyRounding) {
+	if m.UnitValue.Sign() <= 0 {
+		r.errorf(ValidationIssue{Code: IssueUnitValue, Field: "unitValue"}, "unit value %s must be positive", m.UnitValue)
+	} else if rounded := rounding.Round(m.UnitValue); !rounded.Equal(m.UnitValue) {
+		r.errorf(ValidationIssue{Code: IssueUnitValue, Field: "unitValue"},
+			"unit value %s is not rounded to %d places for %s, use %s", m.UnitValue, rounding.Places, m.Currency, rounded)
+	}
+	if m.MinUnitPerLine.GreaterThan(m.MaxUnitPerLine) {
+		r.errorf(ValidationIssue{Code: IssueUnitBounds, Field: "minUnitPerLine"},
+			"minimum units per line %s exceed the maximum %s", m.MinUnitPerLine, m.MaxUnitPerLine)
+	}
+	if m.MinUnitPerTicket.GreaterThan(m.MaxUnitPerTicket) {
+		r.errorf(ValidationIssue{Code: IssueUnitBounds, Field: "minUnitPerTicket"},
+			"minimum units per ticket %s exceed the maximum %s", m.MinUnitPerTicket, m.MaxUnitPerTicket)
+	}
+	if m.MaxUnitPerLine.GreaterThan(m.MaxUnitPerTicket) {
+		r.warnf(ValidationIssue{Code: IssueUnitBounds, Field: "maxUnitPerLine"},
+			"maximum units per line %s exceed the maximum per ticket %s", m.MaxUnitPerLine, m.MaxUnitPerTicket)
+	}
+}
+
+func validateLegs(r *ValidationReport, poolType Type, game string, legs []*validationLeg, legCounts map[int]bool, now time.Time, config ValidationConfig) {
+	if len(legs) == 0 {
+		r.errorf(ValidationIssue{Code: IssueNoLegs, Field: "legs"}, "pool has no legs")
+		return
+	}
+
+	// Trading closes ahead of the first leg that is not void, see Closer.
+	var closeAt time.Time
+	for _, leg := range legs {
+		if containsString(voidMatchStatuses, leg.MatchStatus) {
+			continue
+		}
+		if closeAt.IsZero() || leg.StartTime.Before(closeAt) {
+			closeAt = leg.StartTime
+		}
+	}
+	if !closeAt.IsZero() {
+		closeAt = closeAt.Add(-config.TradingCloseOffset)
+	}
+
+	seen := map[string]string{}
+	for _, leg := range legs {
+		issue := ValidationIssue{LegID: leg.ID, MatchID: leg.MatchID}
+		if other, ok := seen[leg.MatchID]; ok {
+			issue.Code = IssueDuplicateMatch
+			r.errorf(issue, "match %s is also used by leg %s", leg.MatchID, other)
+		}
+		seen[leg.MatchID] = leg.ID
+
+		if !leg.MatchActive {
+			issue.Code = IssueInactiveMatch
+			r.errorf(issue, "match %s is inactive", leg.MatchID)
+		}
+		if !leg.EventActive {
+			issue.Code = IssueInactiveEvent
+			r.errorf(issue, "the event of match %s is inactive", leg.MatchID)
+		}
+		if leg.Game != game {
+			issue.Code = IssueGameMismatch
+			r.errorf(issue, "match %s is %s, pool is %s", leg.MatchID, leg.Game, game)
+		}
+		switch {
+		case containsString(voidMatchStatuses, leg.MatchStatus):
+			issue.Code = IssueVoidedMatch
+			r.errorf(issue, "match %s is %s", leg.MatchID, leg.MatchStatus)
+		case !containsString(notStartedMatchStatuses, leg.MatchStatus),
+			!leg.StartTime.After(now) && leg.MatchStatus != "DELAYED":
+			issue.Code = IssueMatchStarted
+			r.errorf(issue, "match %s has already started", leg.MatchID)
+		}
+		if !closeAt.IsZero() && leg.StartTime.After(closeAt.Add(config.MaxLegSpread)) {
+			issue.Code = IssueLateLeg
+			r.warnf(issue, "match %s starts %s after trading closes", leg.MatchID, leg.StartTime.Sub(closeAt))
+		}
+		if poolType == TypeOverUnder && !leg.Threshold.Valid {
+			issue.Code = IssueMissingThreshold
+			r.errorf(issue, "over/under leg on match %s has no threshold", leg.MatchID)
+		}
+		if poolType == TypeFantasy && !leg.PlayerID.Valid {
+			issue.Code = IssueMissingPlayer
+			r.errorf(issue, "fantasy leg on match %s has no player", leg.MatchID)
+		}
+	}
+
+	if !legCounts[len(legs)] {
+		r.errorf(ValidationIssue{Code: IssueLegCount, Field: "legs"},
+			"no %s %s pool default has %d legs", game, poolType, len(legs))
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func loadValidationLegs(q Querier, id string) ([]*validationLeg, error) {
+	rows, err := q.Query(`
+		SELECT l.id, l.match_id, coalesce(m.is_active, FALSE), coalesce(e.is_active, FALSE),
+		       coalesce(e.game, ''), coalesce(m.internal_status, 'NOT_READY'), m.start_time,
+		       l.threshold, l.player_id::text
+		FROM legs l
+		JOIN matches m ON m.id = l.match_id
+		LEFT JOIN events e ON e.id = m.event_id
+		WHERE l.pool_id = $1 AND l.reserve_position IS NULL AND l.replaced_by IS NULL
+		ORDER BY m.start_time, l.id`, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading legs of pool %s", id)
+	}
+	defer rows.Close()
+
+	var legs []*validationLeg
+	for rows.Next() {
+		leg := &validationLeg{}
+		if err := rows.Scan(&leg.ID, &leg.MatchID, &leg.MatchActive, &leg.EventActive, &leg.Game,
+			&leg.MatchStatus, &leg.StartTime, &leg.Threshold, &leg.PlayerID); err != nil {
+			return nil, errors.Wrap(err, "scanning leg")
+		}
+		legs = append(legs, leg)
+	}
+	return legs, errors.Wrap(rows.Err(), "reading legs")
+}
+
+// defaultLegCounts returns the leg counts of the pool defaults of game and
+// poolType.
+func defaultLegCounts(q Querier, game, poolType string) (map[int]bool, error) {
+	rows, err := q.Query(`SELECT leg_count FROM pool_defaults WHERE game = $1 AND type = $2 AND leg_count > 0`, game, poolType)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading pool default leg counts")
+	}
+	defer rows.Close()
+
+	counts := map[int]bool{}
+	for rows.Next() {
+		var count decimal.Decimal
+		if err := rows.Scan(&count); err != nil {
+			return nil, errors.Wrap(err, "scanning leg count")
+		}
+		counts[int(count.IntPart())] = true
+	}
+	return counts, errors.Wrap(rows.Err(), "reading pool default leg counts")
+}
@@ -0,0 +1,163 @@
+package pools
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// issueKeys lists issues in report order as CODE@leg for leg issues and
+// CODE/field otherwise.
+func issueKeys(issues []*ValidationIssue) []string {
+	keys := []string{}
+	for _, issue := range issues {
+		key := issue.Code
+		if issue.LegID != "" {
+			key += "@" + issue.LegID
+		} else if issue.Field != "" {
+			key += "/" + issue.Field
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestValidateMoney(t *testing.T) {
+	cents := &CurrencyRounding{Currency: CurrencyEUR, Places: 2, Mode: RoundDown}
+	valid := MoneySettings{
+		Currency:         CurrencyEUR,
+		UnitValue:        mustDecimal("0.5"),
+		MinUnitPerLine:   mustDecimal("1"),
+		MaxUnitPerLine:   mustDecimal("10"),
+		MinUnitPerTicket: mustDecimal("1"),
+		MaxUnitPerTicket: mustDecimal("100"),
+	}
+
+	tests := []struct {
+		name     string
+		change   func(m *MoneySettings)
+		errors   []string
+		warnings []string
+	}{
+		{name: "valid", change: func(m *MoneySettings) {}},
+		{name: "zero unit value", change: func(m *MoneySettings) { m.UnitValue = decimal.Zero }, errors: []string{"UNIT_VALUE/unitValue"}},
+		{name: "negative unit value", change: func(m *MoneySettings) { m.UnitValue = mustDecimal("-1") }, errors: []string{"UNIT_VALUE/unitValue"}},
+		{name: "unrounded unit value", change: func(m *MoneySettings) { m.UnitValue = mustDecimal("0.125") }, errors: []string{"UNIT_VALUE/unitValue"}},
+		{name: "line minimum above maximum", change: func(m *MoneySettings) { m.MinUnitPerLine = mustDecimal("11") }, errors: []string{"UNIT_BOUNDS/minUnitPerLine"}},
+		{name: "line minimum at maximum", change: func(m *MoneySettings) { m.MinUnitPerLine = mustDecimal("10") }},
+		{name: "ticket minimum above maximum", change: func(m *MoneySettings) { m.MinUnitPerTicket = mustDecimal("101") }, errors: []string{"UNIT_BOUNDS/minUnitPerTicket"}},
+		{name: "line maximum above ticket maximum", change: func(m *MoneySettings) { m.MaxUnitPerLine = mustDecimal("101") }, warnings: []string{"UNIT_BOUNDS/maxUnitPerLine"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid
+			tt.change(&m)
+			r := &ValidationReport{Errors: []*ValidationIssue{}, Warnings: []*ValidationIssue{}}
+			validateMoney(r, &m, cents)
+			if got, want := issueKeys(r.Errors), append([]string{}, tt.errors...); !reflect.DeepEqual(got, want) {
+				t.Errorf("got errors %v, want %v", got, want)
+			}
+			if got, want := issueKeys(r.Warnings), append([]string{}, tt.warnings...); !reflect.DeepEqual(got, want) {
+				t.Errorf("got warnings %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestValidateLegs(t *testing.T) {
+	now := time.Date(2019, 6, 1, 18, 0, 0, 0, time.UTC)
+	config := ValidationConfig{TradingCloseOffset: 5 * time.Minute, MaxLegSpread: 48 * time.Hour}
+	legCounts := map[int]bool{2: true}
+	// closeAt is when trading closes for legs built by pair.
+	closeAt := now.Add(time.Hour - config.TradingCloseOffset)
+
+	leg := func(id, matchID string) *validationLeg {
+		return &validationLeg{
+			ID:          id,
+			MatchID:     matchID,
+			MatchActive: true,
+			EventActive: true,
+			Game:        "CSGO",
+			MatchStatus: "SCHEDULED",
+			StartTime:   now.Add(time.Hour),
+			Threshold:   decimal.NullDecimal{Decimal: mustDecimal("26.5"), Valid: true},
+			PlayerID:    sql.NullString{String: "player", Valid: true},
+		}
+	}
+	pair := func(change func(first, second *validationLeg)) []*validationLeg {
+		first, second := leg("l1", "m1"), leg("l2", "m2")
+		change(first, second)
+		return []*validationLeg{first, second}
+	}
+
+	tests := []struct {
+		name     string
+		poolType Type
+		legs     []*validationLeg
+		errors   []string
+		warnings []string
+	}{
+		{name: "valid", legs: pair(func(a, b *validationLeg) {})},
+		{name: "no legs", errors: []string{"NO_LEGS/legs"}},
+		{name: "duplicate match", legs: pair(func(a, b *validationLeg) { b.MatchID = "m1" }), errors: []string{"DUPLICATE_MATCH@l2"}},
+		{name: "inactive match", legs: pair(func(a, b *validationLeg) { a.MatchActive = false }), errors: []string{"INACTIVE_MATCH@l1"}},
+		{name: "inactive event", legs: pair(func(a, b *validationLeg) { b.EventActive = false }), errors: []string{"INACTIVE_EVENT@l2"}},
+		{name: "game mismatch", legs: pair(func(a, b *validationLeg) { a.Game = "DOTA2" }), errors: []string{"GAME_MISMATCH@l1"}},
+		{name: "cancelled match", legs: pair(func(a, b *validationLeg) { a.MatchStatus = "CANCELLED" }), errors: []string{"VOIDED_MATCH@l1"}},
+		{
+			name:   "postponed match in the past",
+			legs:   pair(func(a, b *validationLeg) { a.MatchStatus, a.StartTime = "POSTPONED", now.Add(-time.Hour) }),
+			errors: []string{"VOIDED_MATCH@l1"},
+		},
+		{name: "match in progress", legs: pair(func(a, b *validationLeg) { a.MatchStatus = "IN_PROGRESS" }), errors: []string{"MATCH_STARTED@l1"}},
+		{name: "scheduled start passed", legs: pair(func(a, b *validationLeg) { a.StartTime = now }), errors: []string{"MATCH_STARTED@l1"}},
+		{name: "delayed past its start", legs: pair(func(a, b *validationLeg) { a.MatchStatus, a.StartTime = "DELAYED", now.Add(-time.Hour) })},
+		{
+			name:     "leg starts too long after trading closes",
+			legs:     pair(func(a, b *validationLeg) { b.StartTime = closeAt.Add(config.MaxLegSpread + time.Minute) }),
+			warnings: []string{"LEG_STARTS_AFTER_TRADING_CLOSE@l2"},
+		},
+		{name: "leg starts at the spread limit", legs: pair(func(a, b *validationLeg) { b.StartTime = closeAt.Add(config.MaxLegSpread) })},
+		{
+			name: "void leg does not move trading close",
+			legs: pair(func(a, b *validationLeg) {
+				a.MatchStatus = "CANCELLED"
+				b.StartTime = now.Add(72 * time.Hour)
+			}),
+			errors: []string{"VOIDED_MATCH@l1"},
+		},
+		{
+			name:     "over/under threshold missing",
+			poolType: TypeOverUnder,
+			legs:     pair(func(a, b *validationLeg) { b.Threshold = decimal.NullDecimal{} }),
+			errors:   []string{"MISSING_THRESHOLD@l2"},
+		},
+		{name: "head to head without threshold", legs: pair(func(a, b *validationLeg) { a.Threshold = decimal.NullDecimal{} })},
+		{
+			name:     "fantasy player missing",
+			poolType: TypeFantasy,
+			legs:     pair(func(a, b *validationLeg) { a.PlayerID = sql.NullString{} }),
+			errors:   []string{"MISSING_PLAYER@l1"},
+		},
+		{name: "leg count without a pool default", legs: []*validationLeg{leg("l1", "m1")}, errors: []string{"LEG_COUNT/legs"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poolType := tt.poolType
+			if poolType == "" {
+				poolType = TypeH2H
+			}
+			r := &ValidationReport{Errors: []*ValidationIssue{}, Warnings: []*ValidationIssue{}}
+			validateLegs(r, poolType, "CSGO", tt.legs, legCounts, now, config)
+			if got, want := issueKeys(r.Errors), append([]string{}, tt.errors...); !reflect.DeepEqual(got, want) {
+				t.Errorf("got errors %v, want %v", got, want)
+			}
+			if got, want := issueKeys(r.Warnings), append([]string{}, tt.warnings...); !reflect.DeepEqual(got, want) {
+				t.Errorf("got warnings %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package pools
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// VoidRule mirrors the VoidRule GraphQL enum: what settlement does with
+// legs whose match ended without a result.
+type VoidRule string
+
+const (
+	// VoidAllWin counts a void leg as won by every selection.
+	VoidAllWin VoidRule = "ALL_WIN"
+	// VoidSubstitute replaces a void leg with the pool's next reserve leg
+	// while trading is open. Legs voided after trading closed count as
+	// all-win.
+	VoidSubstitute VoidRule = "SUBSTITUTE"
+	// VoidAbandon is recorded on a pool abandoned for having too many void
+	// legs. It is not a configurable rule.
+	VoidAbandon VoidRule = "ABANDON"
+)
+
+// UnmarshalGQL implements the gqlgen Unmarshaler for the VoidRule enum.
+func (r *VoidRule) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("VoidRule must be a string")
+	}
+	*r = VoidRule(str)
+	return nil
+}
+
+// MarshalGQL implements the gqlgen Marshaler for the VoidRule enum.
+func (r VoidRule) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(r)))
+}
+
+// ConfigurableVoidRule mirrors the ConfigurableVoidRule GraphQL enum: the
+// void rules a pool type can be set to. It excludes VoidAbandon.
+type ConfigurableVoidRule VoidRule
+
+// IsValid reports whether r is one of the ConfigurableVoidRule values.
+func (r ConfigurableVoidRule) IsValid() bool {
+	switch VoidRule(r) {
+	case VoidAllWin, VoidSubstitute:
+		return true
+	}
+	return false
+}
+
+// UnmarshalGQL implements the gqlgen Unmarshaler for the
+// ConfigurableVoidRule enum.
+func (r *ConfigurableVoidRule) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("ConfigurableVoidRule must be a string")
+	}
+	*r = ConfigurableVoidRule(str)
+	if !r.IsValid() {
+		return fmt.Errorf("%s is not a valid ConfigurableVoidRule", str)
+	}
+	return nil
+}
+
+// MarshalGQL implements the gqlgen Marshaler for the ConfigurableVoidRule
+// enum.
+func (r ConfigurableVoidRule) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(string(r)))
+}
+
+// PoolVoidRule is the void handling of one pool type. A pool with more
+// than AbandonAbove void legs is abandoned; nil never abandons.
+type PoolVoidRule struct {
+	Type         Type     `json:"type"`
+	Rule         VoidRule `json:"rule"`
+	AbandonAbove *int     `json:"abandonAbove"`
+	Note         string   `json:"note"`
+}
+
+// defaultVoidRule applies to pool types without a pool_void_rules row.
+func defaultVoidRule(poolType Type) *PoolVoidRule {
+	return &PoolVoidRule{Type: poolType, Rule: VoidAllWin}
+}
+
+// apply returns the rule settlement applies to a pool with voids void legs,
+// or "" when no leg is void.
+func (r *PoolVoidRule) apply(voids int) VoidRule {
+	switch {
+	case voids == 0:
+		return ""
+	case r.AbandonAbove != nil && voids > *r.AbandonAbove:
+		return VoidAbandon
+	default:
+		// Substitution happens before trading closes; what is still void
+		// at settlement counts as all-win.
+		return VoidAllWin
+	}
+}
+
+// LoadVoidRule returns the void rule of poolType.
+func LoadVoidRule(q Querier, poolType Type) (*PoolVoidRule, error) {
+	r := &PoolVoidRule{Type: poolType}
+	var abandonAbove sql.NullInt64
+	var note sql.NullString
+	err := q.QueryRow(`SELECT rule, abandon_above, note FROM pool_void_rules WHERE type = $1`, string(poolType)).
+		Scan(&r.Rule, &abandonAbove, &note)
+	if err == sql.ErrNoRows {
+		return defaultVoidRule(poolType), nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading void rule of %s pools", poolType)
+	}
+	if abandonAbove.Valid {
+		n := int(abandonAbove.Int64)
+		r.AbandonAbove = &n
+	}
+	r.Note = note.String
+	return r, nil
+}
+
+// VoidRules returns the configured void rules by pool type.
+func VoidRules(q Querier) ([]*PoolVoidRule, error) {
+	rows, err := q.Query(`SELECT type, rule, abandon_above, coalesce(note, '') FROM pool_void_rules ORDER BY type`)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading void rules")
+	}
+	defer rows.Close()
+
+	var rules []*PoolVoidRule
+	for rows.Next() {
+		r := &PoolVoidRule{}
+		var abandonAbove sql.NullInt64
+		if err := rows.Scan(&r.Type, &r.Rule, &abandonAbove, &r.Note); err != nil {
+			return nil, errors.Wrap(err, "scanning void rule")
+		}
+		if abandonAbove.Valid {
+			n := int(abandonAbove.Int64)
+			r.AbandonAbove = &n
+		}
+		rules = append(rules, r)
+	}
+	return rules, errors.Wrap(rows.Err(), "reading void rules")
+}
+
+// SetVoidRule creates or replaces the void rule of r.Type.
+func SetVoidRule(q Querier, r *PoolVoidRule) error {
+	if !ConfigurableVoidRule(r.Rule).IsValid() {
+		return errors.Errorf("void rule must be %s or %s, got %s", VoidAllWin, VoidSubstitute, r.Rule)
+	}
+	if r.AbandonAbove != nil && *r.AbandonAbove < 0 {
+		return errors.Errorf("abandonAbove must not be negative, got %d", *r.AbandonAbove)
+	}
+	var abandonAbove sql.NullInt64
+	if r.AbandonAbove != nil {
+		abandonAbove = sql.NullInt64{Int64: int64(*r.AbandonAbove), Valid: true}
+	}
+	_, err := q.Exec(`
+		INSERT INTO pool_void_rules (type, rule, abandon_above, note)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (type) DO UPDATE
+		SET rule = EXCLUDED.rule, abandon_above = EXCLUDED.abandon_above, note = EXCLUDED.note`,
+		string(r.Type), string(r.Rule), abandonAbove, r.Note)
+	return errors.Wrapf(err, "saving void rule of %s pools", r.Type)
+}
+
+// countVoid returns the number of void results.
+func countVoid(results []*LegResult) int {
+	n := 0
+	for _, result := range results {
+		if result.Outcome == OutcomeVoid {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,53 @@
+package pools
+
+import "testing"
+
+func TestPoolVoidRuleApply(t *testing.T) {
+	two := 2
+	zero := 0
+	tests := []struct {
+		name         string
+		rule         VoidRule
+		abandonAbove *int
+		voids        int
+		want         VoidRule
+	}{
+		{name: "no voids", rule: VoidAllWin, abandonAbove: &two, voids: 0, want: ""},
+		{name: "no voids, never abandons", rule: VoidAllWin, voids: 0, want: ""},
+		{name: "never abandons", rule: VoidAllWin, voids: 5, want: VoidAllWin},
+		{name: "below the limit", rule: VoidAllWin, abandonAbove: &two, voids: 1, want: VoidAllWin},
+		{name: "at the limit", rule: VoidAllWin, abandonAbove: &two, voids: 2, want: VoidAllWin},
+		{name: "above the limit", rule: VoidAllWin, abandonAbove: &two, voids: 3, want: VoidAbandon},
+		{name: "any void abandons", rule: VoidAllWin, abandonAbove: &zero, voids: 1, want: VoidAbandon},
+		{name: "substitute settles as all-win", rule: VoidSubstitute, voids: 1, want: VoidAllWin},
+		{name: "substitute at the limit", rule: VoidSubstitute, abandonAbove: &two, voids: 2, want: VoidAllWin},
+		{name: "substitute above the limit", rule: VoidSubstitute, abandonAbove: &two, voids: 3, want: VoidAbandon},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PoolVoidRule{Type: TypeH2H, Rule: tt.rule, AbandonAbove: tt.abandonAbove}
+			if got := r.apply(tt.voids); got != tt.want {
+				t.Errorf("apply(%d) = %q, want %q", tt.voids, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigurableVoidRuleUnmarshalGQL(t *testing.T) {
+	tests := []struct {
+		input interface{}
+		valid bool
+	}{
+		{"ALL_WIN", true},
+		{"SUBSTITUTE", true},
+		{"ABANDON", false},
+		{"", false},
+		{1, false},
+	}
+	for _, tt := range tests {
+		var r ConfigurableVoidRule
+		if err := r.UnmarshalGQL(tt.input); tt.valid != (err == nil) {
+			t.Errorf("UnmarshalGQL(%v) error = %v, want valid %v", tt.input, err, tt.valid)
+		}
+	}
+}
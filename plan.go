@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	dropTableRegexp  = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?([\w."]+)`)
+	dropColumnRegexp = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?([\w."]+)\s.*\bDROP\s+COLUMN\b`)
+	truncateRegexp   = regexp.MustCompile(`(?is)^TRUNCATE\s+(?:TABLE\s+)?([\w."]+)`)
+	bulkWriteRegexp  = regexp.MustCompile(`(?is)^(UPDATE|DELETE\s+FROM)\s+([\w."]+)`)
+	whereRegexp      = regexp.MustCompile(`(?i)\bWHERE\b`)
+	dollarTagRegexp  = regexp.MustCompile(`^\$(?:[A-Za-z_][A-Za-z0-9_]*)?\$`)
+)
+
+// destructiveWarnings describes every way the statement can lose data.
+func destructiveWarnings(statement string) []string {
+	var warnings []string
+	if match := dropTableRegexp.FindStringSubmatch(statement); match != nil {
+		warnings = append(warnings, fmt.Sprintf("DROP TABLE %s", match[1]))
+	}
+	if match := dropColumnRegexp.FindStringSubmatch(statement); match != nil {
+		warnings = append(warnings, fmt.Sprintf("DROP COLUMN on %s", match[1]))
+	}
+	if match := truncateRegexp.FindStringSubmatch(statement); match != nil {
+		warnings = append(warnings, fmt.Sprintf("TRUNCATE %s", match[1]))
+	}
+	if match := bulkWriteRegexp.FindStringSubmatch(statement); match != nil && !whereRegexp.MatchString(statement) {
+		verb := strings.ToUpper(strings.Fields(match[1])[0])
+		warnings = append(warnings, fmt.Sprintf("bulk %s of every row in %s", verb, match[2]))
+	}
+	return warnings
+}
+
+// splitStatements splits a SQL script on semicolons outside of quotes,
+// dollar-quoted bodies ($$ ... $$ or $tag$ ... $tag$) and comments.
+func splitStatements(script string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+	)
+	flush := func() {
+		if s := strings.TrimSpace(current.String()); s != "" {
+			statements = append(statements, s)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(script[i+1:], c)
+			if end < 0 {
+				current.WriteString(script[i:])
+				i = len(script)
+				continue
+			}
+			current.WriteString(script[i : i+end+2])
+			i += end + 1
+		case c == '-' && strings.HasPrefix(script[i:], "--"):
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				i = len(script)
+				continue
+			}
+			i += end
+			current.WriteByte('\n')
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+				continue
+			}
+			i += end + 3
+			current.WriteByte(' ')
+		case c == '$' && (i == 0 || !isIdentByte(script[i-1])) && dollarTagRegexp.MatchString(script[i:]):
+			tag := dollarTagRegexp.FindString(script[i:])
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				current.WriteString(script[i:])
+				i = len(script)
+				continue
+			}
+			current.WriteString(script[i : i+2*len(tag)+end])
+			i += 2*len(tag) + end - 1
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return statements
+}
+
+// isIdentByte reports whether c may appear inside an unquoted identifier,
+// where a $ does not start a dollar quote.
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// PlanStep is one migration the plan would run, split into statements.
+type PlanStep struct {
+	Migration  *Migration
+	Direction  Direction
+	Statements []string
+	Warnings   []string
+}
+
+// Plan lists the migrations needed to move from current to target, in the
+// order they would run. Versions above current are applied up to target;
+// when target is below current the applied versions above it are reverted.
+func (r *Registry) Plan(current uint, applied bool, target uint) ([]PlanStep, error) {
+	var migrations []*Migration
+	direction := DirectionUp
+	if applied && target < current {
+		direction = DirectionDown
+		all := r.Migrations()
+		for i := len(all) - 1; i >= 0; i-- {
+			if v := all[i].Version; v <= current && v > target {
+				migrations = append(migrations, all[i])
+			}
+		}
+	} else {
+		for _, m := range r.Migrations() {
+			if (!applied || m.Version > current) && m.Version <= target {
+				migrations = append(migrations, m)
+			}
+		}
+	}
+
+	steps := make([]PlanStep, 0, len(migrations))
+	for _, m := range migrations {
+		step := PlanStep{Migration: m, Direction: direction}
+		if m.File(direction).IsGo() {
+			step.Warnings = []string{"Go migration, its statements are only known when it runs"}
+			steps = append(steps, step)
+			continue
+		}
+		if m.File(direction).IsLegacy() {
+			step.Warnings = []string{"legacy migration golang-migrate never ran, it is skipped"}
+			steps = append(steps, step)
+			continue
+		}
+		data, err := m.File(direction).SQL()
+		if err != nil {
+			return nil, err
+		}
+		step.Statements = splitStatements(string(data))
+		for _, statement := range step.Statements {
+			step.Warnings = append(step.Warnings, destructiveWarnings(statement)...)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// writePlan prints the plan as a SQL script with the warnings as comments.
+func writePlan(w io.Writer, steps []PlanStep) {
+	var warnings int
+	for _, step := range steps {
+		file := step.Migration.File(step.Direction)
+		fmt.Fprintf(w, "\n-- %d %s (%s) from %s\n", step.Migration.Version, step.Migration.Name, step.Direction, file.Asset)
+		fmt.Fprintf(w, "-- sha256 %s\n", file.Checksum)
+		for _, warning := range step.Warnings {
+			fmt.Fprintf(w, "-- WARNING: %s\n", warning)
+		}
+		warnings += len(step.Warnings)
+		for _, statement := range step.Statements {
+			fmt.Fprintf(w, "%s;\n", statement)
+		}
+	}
+	fmt.Fprintf(w, "\n-- %d migrations, %d warnings\n", len(steps), warnings)
+}
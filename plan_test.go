@@ -0,0 +1,103 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "plain",
+			script: "CREATE TABLE a (id int);\nDROP TABLE b;\n",
+			want:   []string{"CREATE TABLE a (id int)", "DROP TABLE b"},
+		},
+		{
+			name:   "no trailing semicolon",
+			script: "SELECT 1; SELECT 2",
+			want:   []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:   "quoted semicolons",
+			script: `INSERT INTO a VALUES ('x;y'); ALTER TABLE "odd;name" ADD COLUMN b int;`,
+			want:   []string{`INSERT INTO a VALUES ('x;y')`, `ALTER TABLE "odd;name" ADD COLUMN b int`},
+		},
+		{
+			name:   "escaped quote",
+			script: "INSERT INTO a VALUES ('it''s; fine');",
+			want:   []string{"INSERT INTO a VALUES ('it''s; fine')"},
+		},
+		{
+			name:   "line comments",
+			script: "-- first; not a statement\nSELECT 1; -- trailing; comment\nSELECT 2;",
+			want:   []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:   "block comments",
+			script: "/* setup; */ SELECT 1; SELECT /* inline; */ 2;",
+			want:   []string{"SELECT 1", "SELECT   2"},
+		},
+		{
+			name:   "dollar quoted body",
+			script: "CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql; SELECT f();",
+			want: []string{
+				"CREATE FUNCTION f() RETURNS int AS $$ BEGIN RETURN 1; END; $$ LANGUAGE plpgsql",
+				"SELECT f()",
+			},
+		},
+		{
+			name:   "tagged dollar quote",
+			script: "DO $body$ BEGIN PERFORM 'a;b'; RAISE NOTICE '$$'; END $body$; SELECT 1;",
+			want: []string{
+				"DO $body$ BEGIN PERFORM 'a;b'; RAISE NOTICE '$$'; END $body$",
+				"SELECT 1",
+			},
+		},
+		{
+			name:   "positional parameters are not quotes",
+			script: "PREPARE p AS SELECT $1; EXECUTE p(1);",
+			want:   []string{"PREPARE p AS SELECT $1", "EXECUTE p(1)"},
+		},
+		{
+			name:   "empty statements",
+			script: ";;\n  ;",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitStatements(%q)\n got %q\nwant %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDestructiveWarnings(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      []string
+	}{
+		{`CREATE TABLE a (id int)`, nil},
+		{`DROP TABLE "public"."competitors"`, []string{`DROP TABLE "public"."competitors"`}},
+		{`drop table if exists pools`, []string{"DROP TABLE pools"}},
+		{`ALTER TABLE pools DROP COLUMN game`, []string{"DROP COLUMN on pools"}},
+		{`ALTER TABLE pools ADD COLUMN game text`, nil},
+		{`TRUNCATE TABLE legs`, []string{"TRUNCATE legs"}},
+		{`UPDATE pools SET game = 'DOTA2'`, []string{"bulk UPDATE of every row in pools"}},
+		{`UPDATE pools SET game = 'DOTA2' WHERE game IS NULL`, nil},
+		{`DELETE FROM legs`, []string{"bulk DELETE of every row in legs"}},
+		{"delete from legs\nwhere pool_id IS NULL", nil},
+	}
+
+	for _, tt := range tests {
+		if got := destructiveWarnings(tt.statement); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("destructiveWarnings(%q) = %q, want %q", tt.statement, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// migrationsDir is the asset directory holding the embedded SQL migrations.
+const migrationsDir = "migrations"
+
+// migrationFileRegexp matches golang-migrate style names: N_name.up.sql / N_name.down.sql.
+var migrationFileRegexp = regexp.MustCompile(`^([0-9]+)_([a-zA-Z0-9_]+)\.(up|down)\.sql$`)
+
+// legacyMigrationFiles maps assets golang-migrate never matched to the name
+// they were meant to have. Databases it migrated past their version never
+// ran them, so neither half of such a version runs here either: the files
+// stay embedded unchanged and a later migration applies them idempotently.
+var legacyMigrationFiles = map[string]string{
+	// Recreated by 42_recreate_competitors.
+	"30_competitors_up.sql": "30_competitors.up.sql",
+}
+
+// Direction is the half of a migration pair an asset belongs to.
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+// MigrationFile is one half of a migration: an embedded SQL asset or, for
+// Go migrations, a registered function.
+type MigrationFile struct {
+	Asset     string
+	Direction Direction
+	Checksum  string
+
+	fsys          fs.FS
+	fn            func(q querier) error
+	noTransaction bool
+	legacy        bool
+}
+
+// IsGo reports whether the file is a Go migration rather than SQL.
+func (f *MigrationFile) IsGo() bool {
+	return f.fn != nil
+}
+
+// IsLegacy reports whether the file belongs to a version golang-migrate
+// never ran, see legacyMigrationFiles. Running it does nothing.
+func (f *MigrationFile) IsLegacy() bool {
+	return f.legacy
+}
+
+// SQL returns the decompressed contents of the asset.
+func (f *MigrationFile) SQL() ([]byte, error) {
+	if f.IsGo() {
+		return nil, errors.Errorf("%s is a Go migration", f.Asset)
+	}
+	return fs.ReadFile(f.fsys, f.Asset)
+}
+
+// body returns the function running the file and whether it may run inside
+// a transaction.
+func (f *MigrationFile) body() (func(q querier) error, bool, error) {
+	if f.IsGo() {
+		return f.fn, !f.noTransaction, nil
+	}
+	if f.IsLegacy() {
+		return func(q querier) error { return nil }, true, nil
+	}
+	data, err := f.SQL()
+	if err != nil {
+		return nil, false, err
+	}
+	query := string(data)
+	run := func(q querier) error {
+		_, err := q.Exec(query)
+		return err
+	}
+	return run, !noTransactionRegexp.MatchString(query), nil
+}
+
+// Migration is a versioned up/down pair of embedded SQL assets.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      *MigrationFile
+	Down    *MigrationFile
+}
+
+// File returns the asset for the given direction.
+func (m *Migration) File(direction Direction) *MigrationFile {
+	if direction == DirectionDown {
+		return m.Down
+	}
+	return m.Up
+}
+
+// RegistryError lists every problem found while loading the embedded migrations.
+type RegistryError struct {
+	Problems []string
+}
+
+func (e *RegistryError) Error() string {
+	return fmt.Sprintf("invalid embedded migrations: %s", strings.Join(e.Problems, "; "))
+}
+
+// Registry is the ordered set of migrations embedded in the binary.
+type Registry struct {
+	migrations []*Migration
+	byVersion  map[uint]*Migration
+}
+
+// NewRegistry parses every .sql file in dir of fsys into an ordered
+// registry, interleaved by version with the registered Go migrations. It
+// fails when a file name is malformed, a version is duplicated or an
+// up/down pair is incomplete. Versions with a file in legacyMigrationFiles
+// are kept but never run. The binary passes AssetFS(); tests and tools
+// may pass any other file system, e.g. os.DirFS.
+func NewRegistry(fsys fs.FS, dir string) (*Registry, error) {
+	r := &Registry{byVersion: map[uint]*Migration{}}
+	var problems []string
+	legacyVersions := map[uint]bool{}
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", dir)
+	}
+	for _, entry := range entries {
+		base := entry.Name()
+		if entry.IsDir() || path.Ext(base) != ".sql" {
+			continue
+		}
+		name := path.Join(dir, base)
+		intended, legacy := legacyMigrationFiles[base]
+		if !legacy {
+			intended = base
+		}
+		match := migrationFileRegexp.FindStringSubmatch(intended)
+		if match == nil {
+			problems = append(problems, fmt.Sprintf("%s: name must match N_name.up.sql or N_name.down.sql", name))
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid version: %v", name, err))
+			continue
+		}
+		if legacy {
+			legacyVersions[uint(version)] = true
+		}
+		checksum, err := fileChecksum(fsys, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", name)
+		}
+		file := &MigrationFile{
+			Asset:     name,
+			Direction: Direction(match[3]),
+			Checksum:  checksum,
+			fsys:      fsys,
+		}
+
+		m, ok := r.byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			r.byVersion[m.Version] = m
+			r.migrations = append(r.migrations, m)
+		}
+		switch file.Direction {
+		case DirectionUp:
+			if m.Up != nil {
+				problems = append(problems, fmt.Sprintf("version %d: duplicate up migrations %s and %s", m.Version, m.Up.Asset, name))
+				continue
+			}
+			// The up file names the migration when the pair disagrees.
+			m.Name = match[2]
+			m.Up = file
+		case DirectionDown:
+			if m.Down != nil {
+				problems = append(problems, fmt.Sprintf("version %d: duplicate down migrations %s and %s", m.Version, m.Down.Asset, name))
+				continue
+			}
+			m.Down = file
+		}
+	}
+
+	for _, g := range goMigrations {
+		if g.Up == nil || g.Down == nil {
+			problems = append(problems, fmt.Sprintf("version %d: Go migration %s needs both Up and Down", g.Version, g.Name))
+			continue
+		}
+		if existing, ok := r.byVersion[g.Version]; ok {
+			problems = append(problems, fmt.Sprintf("version %d: Go migration %s clashes with %s", g.Version, g.Name, existing.Name))
+			continue
+		}
+		m := &Migration{Version: g.Version, Name: g.Name, Up: g.file(DirectionUp), Down: g.file(DirectionDown)}
+		r.byVersion[m.Version] = m
+		r.migrations = append(r.migrations, m)
+	}
+
+	sort.Slice(r.migrations, func(i, j int) bool {
+		return r.migrations[i].Version < r.migrations[j].Version
+	})
+	for _, m := range r.migrations {
+		if legacyVersions[m.Version] {
+			for _, file := range []*MigrationFile{m.Up, m.Down} {
+				if file != nil {
+					file.legacy = true
+				}
+			}
+		}
+		if m.Up == nil {
+			problems = append(problems, fmt.Sprintf("version %d: missing up migration for %s", m.Version, m.Down.Asset))
+		}
+		if m.Down == nil {
+			problems = append(problems, fmt.Sprintf("version %d: missing down migration for %s", m.Version, m.Up.Asset))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, &RegistryError{Problems: problems}
+	}
+	return r, nil
+}
+
+// Migrations returns the migrations ordered by ascending version.
+func (r *Registry) Migrations() []*Migration {
+	return r.migrations
+}
+
+// Lookup returns the migration with the given version.
+func (r *Registry) Lookup(version uint) (*Migration, bool) {
+	m, ok := r.byVersion[version]
+	return m, ok
+}
+
+// Previous returns the migration ordered directly before version.
+func (r *Registry) Previous(version uint) (*Migration, bool) {
+	var previous *Migration
+	for _, m := range r.migrations {
+		if m.Version >= version {
+			break
+		}
+		previous = m
+	}
+	return previous, previous != nil
+}
+
+// Latest returns the highest embedded version, or 0 when there are none.
+func (r *Registry) Latest() uint {
+	if len(r.migrations) == 0 {
+		return 0
+	}
+	return r.migrations[len(r.migrations)-1].Version
+}
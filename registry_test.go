@@ -0,0 +1,152 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func sqlFile(query string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(query)}
+}
+
+func TestNewRegistry(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		versions []uint
+		problems []string
+	}{
+		{
+			name: "ordered pairs",
+			files: fstest.MapFS{
+				"migrations/2_second.up.sql":   sqlFile("CREATE TABLE b ()"),
+				"migrations/2_second.down.sql": sqlFile("DROP TABLE b"),
+				"migrations/10_tenth.up.sql":   sqlFile("CREATE TABLE c ()"),
+				"migrations/10_tenth.down.sql": sqlFile("DROP TABLE c"),
+				"migrations/1_first.up.sql":    sqlFile("CREATE TABLE a ()"),
+				"migrations/1_first.down.sql":  sqlFile("DROP TABLE a"),
+				"migrations/README.md":         sqlFile("not a migration"),
+			},
+			versions: []uint{1, 2, 10, 31},
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"migrations/1_first.up.sql":   sqlFile("CREATE TABLE a ()"),
+				"migrations/1_first.down.sql": sqlFile("DROP TABLE a"),
+				"migrations/1_other.up.sql":   sqlFile("CREATE TABLE b ()"),
+			},
+			problems: []string{"version 1: duplicate up migrations"},
+		},
+		{
+			name: "missing down",
+			files: fstest.MapFS{
+				"migrations/1_first.up.sql": sqlFile("CREATE TABLE a ()"),
+			},
+			problems: []string{"version 1: missing down migration for migrations/1_first.up.sql"},
+		},
+		{
+			name: "missing up",
+			files: fstest.MapFS{
+				"migrations/1_first.down.sql": sqlFile("DROP TABLE a"),
+			},
+			problems: []string{"version 1: missing up migration for migrations/1_first.down.sql"},
+		},
+		{
+			name: "bad names",
+			files: fstest.MapFS{
+				"migrations/1_first_up.sql":    sqlFile("CREATE TABLE a ()"),
+				"migrations/first.down.sql":    sqlFile("DROP TABLE a"),
+				"migrations/2_second.side.sql": sqlFile("SELECT 1"),
+			},
+			problems: []string{
+				"migrations/1_first_up.sql: name must match",
+				"migrations/2_second.side.sql: name must match",
+				"migrations/first.down.sql: name must match",
+			},
+		},
+		{
+			name: "clash with Go migration",
+			files: fstest.MapFS{
+				"migrations/31_clash.up.sql":   sqlFile("SELECT 1"),
+				"migrations/31_clash.down.sql": sqlFile("SELECT 1"),
+			},
+			problems: []string{"version 31: Go migration backfill_competitor_match clashes with clash"},
+		},
+		{
+			name: "legacy name",
+			files: fstest.MapFS{
+				"migrations/30_competitors_up.sql":   sqlFile("CREATE TABLE competitors ()"),
+				"migrations/30_competitors.down.sql": sqlFile("DROP TABLE competitors"),
+			},
+			versions: []uint{30, 31},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRegistry(tt.files, migrationsDir)
+			if len(tt.problems) > 0 {
+				regErr, ok := err.(*RegistryError)
+				if !ok {
+					t.Fatalf("got error %v, want a *RegistryError", err)
+				}
+				if len(regErr.Problems) != len(tt.problems) {
+					t.Fatalf("got problems %q, want %q", regErr.Problems, tt.problems)
+				}
+				for i, want := range tt.problems {
+					if !strings.HasPrefix(regErr.Problems[i], want) {
+						t.Errorf("problem %d is %q, want prefix %q", i, regErr.Problems[i], want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var versions []uint
+			for _, m := range r.Migrations() {
+				versions = append(versions, m.Version)
+				if m.Up == nil || m.Down == nil {
+					t.Errorf("version %d is missing a half", m.Version)
+				}
+			}
+			if len(versions) != len(tt.versions) {
+				t.Fatalf("got versions %v, want %v", versions, tt.versions)
+			}
+			for i := range versions {
+				if versions[i] != tt.versions[i] {
+					t.Fatalf("got versions %v, want %v", versions, tt.versions)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRegistryLegacyVersionIsSkipped(t *testing.T) {
+	r, err := NewRegistry(fstest.MapFS{
+		"migrations/30_competitors_up.sql":   sqlFile("CREATE TABLE competitors ()"),
+		"migrations/30_competitors.down.sql": sqlFile("DROP TABLE competitors"),
+	}, migrationsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, ok := r.Lookup(30)
+	if !ok {
+		t.Fatal("version 30 not registered")
+	}
+	if m.Name != "competitors" {
+		t.Errorf("got name %q, want competitors", m.Name)
+	}
+	if !m.Up.IsLegacy() || !m.Down.IsLegacy() {
+		t.Errorf("both halves of version 30 should be legacy")
+	}
+	steps, err := r.Plan(0, false, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 1 || len(steps[0].Statements) != 0 {
+		t.Errorf("legacy version should plan without statements, got %+v", steps)
+	}
+}
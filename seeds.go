@@ -0,0 +1,170 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// seedsDir holds one asset directory per seed set.
+const seedsDir = "seeds"
+
+// defaultSeedSet is applied in every environment before the environment's own set.
+const defaultSeedSet = "default"
+
+var (
+	seedFileRegexp   = regexp.MustCompile(`^([0-9]+)_([a-zA-Z0-9_]+)\.sql$`)
+	insertRegexp     = regexp.MustCompile(`(?is)^INSERT\s+INTO\b`)
+	onConflictRegexp = regexp.MustCompile(`(?is)\bON\s+CONFLICT\b`)
+)
+
+// Seed is an embedded, idempotent data script. Seeds live outside the
+// schema version and may be re-run at any time.
+type Seed struct {
+	Set      string
+	Order    uint
+	Name     string
+	Asset    string
+	Checksum string
+
+	fsys fs.FS
+}
+
+// SeedSets lists the seed sets in fsys.
+func SeedSets(fsys fs.FS) []string {
+	entries, _ := fs.ReadDir(fsys, seedsDir)
+	var sets []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			sets = append(sets, entry.Name())
+		}
+	}
+	return sets
+}
+
+// LoadSeeds returns the default seeds followed by the seeds of environment
+// from fsys, each set ordered by its numeric prefix. Every INSERT must carry
+// an ON CONFLICT clause so the seeds stay safe to re-run.
+func LoadSeeds(fsys fs.FS, environment string) ([]*Seed, error) {
+	sets := []string{defaultSeedSet}
+	if environment != "" && environment != defaultSeedSet {
+		sets = append(sets, environment)
+	}
+
+	var (
+		seeds    []*Seed
+		problems []string
+	)
+	for _, set := range sets {
+		var setSeeds []*Seed
+		dir := path.Join(seedsDir, set)
+		entries, err := fs.ReadDir(fsys, dir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "reading %s", dir)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			name := path.Join(dir, entry.Name())
+			match := seedFileRegexp.FindStringSubmatch(path.Base(name))
+			if match == nil {
+				problems = append(problems, fmt.Sprintf("%s: name must match N_name.sql", name))
+				continue
+			}
+			order, err := strconv.ParseUint(match[1], 10, 64)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid order: %v", name, err))
+				continue
+			}
+			data, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading %s", name)
+			}
+			for _, statement := range splitStatements(string(data)) {
+				if insertRegexp.MatchString(statement) && !onConflictRegexp.MatchString(statement) {
+					problems = append(problems, fmt.Sprintf("%s: INSERT without ON CONFLICT is not idempotent", name))
+				}
+			}
+			checksum, err := fileChecksum(fsys, name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading %s", name)
+			}
+			setSeeds = append(setSeeds, &Seed{
+				Set:      set,
+				Order:    uint(order),
+				Name:     match[2],
+				Asset:    name,
+				Checksum: checksum,
+				fsys:     fsys,
+			})
+		}
+		if len(setSeeds) == 0 && set != defaultSeedSet {
+			log.WithField("environment", set).Warn("no seeds embedded for environment")
+		}
+		sort.Slice(setSeeds, func(i, j int) bool {
+			return setSeeds[i].Order < setSeeds[j].Order
+		})
+		seeds = append(seeds, setSeeds...)
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.Errorf("invalid embedded seeds: %s", strings.Join(problems, "; "))
+	}
+	return seeds, nil
+}
+
+// Seed runs the seeds against a fully migrated database, each in its own
+// transaction, while holding the migration lock. The schema version is
+// left untouched.
+func (m *Migrator) Seed(seeds []*Seed) error {
+	unlock, err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, dirty, ok, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if !ok || dirty || current != m.registry.Latest() {
+		return errors.Errorf("seeds need a clean database at version %d, run migrate up first", m.registry.Latest())
+	}
+
+	for _, seed := range seeds {
+		query, err := fs.ReadFile(seed.fsys, seed.Asset)
+		if err != nil {
+			return err
+		}
+		logger := log.WithFields(log.Fields{"set": seed.Set, "seed": seed.Name, "pod": m.Owner})
+		var affected int64
+		err = m.inTx(func(tx *sql.Tx) error {
+			for _, statement := range splitStatements(string(query)) {
+				result, err := tx.Exec(statement)
+				if err != nil {
+					return errors.Wrapf(err, "running %s", seed.Asset)
+				}
+				if n, err := result.RowsAffected(); err == nil {
+					affected += n
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		logger.WithField("rows", affected).Info("seed applied")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+-- Pool defaults per game, type and leg count. Keyed on
+-- pool_defaults_uniqueness_on_legcount_game_type, so re-running resets the
+-- money settings of these rows to the values below.
+INSERT INTO pool_defaults (leg_count, game, type, guarantee, carry_in, allocation, unit_value, min_unit_per_line, max_unit_per_line, min_unit_per_ticket, max_unit_per_ticket, currency, note)
+VALUES
+ (4, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'H2H', 200, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (6, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'H2H', 500, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (8, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'H2H', 1000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (10, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'H2H', 2000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (4, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'OVER_UNDER', 200, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (6, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'OVER_UNDER', 500, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (8, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'OVER_UNDER', 1000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (10, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'OVER_UNDER', 2000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (6, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'FANTASY', 5000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (10, 'COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'FANTASY', 10000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (4, 'DOTA_2', 'H2H', 200, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (6, 'DOTA_2', 'H2H', 500, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (8, 'DOTA_2', 'H2H', 1000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (10, 'DOTA_2', 'H2H', 2000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (4, 'DOTA_2', 'OVER_UNDER', 200, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (6, 'DOTA_2', 'OVER_UNDER', 500, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (8, 'DOTA_2', 'OVER_UNDER', 1000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (10, 'DOTA_2', 'OVER_UNDER', 2000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (6, 'DOTA_2', 'FANTASY', 5000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (10, 'DOTA_2', 'FANTASY', 10000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (4, 'LEAGUE_OF_LEGENDS', 'H2H', 200, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (6, 'LEAGUE_OF_LEGENDS', 'H2H', 500, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (8, 'LEAGUE_OF_LEGENDS', 'H2H', 1000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (10, 'LEAGUE_OF_LEGENDS', 'H2H', 2000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (4, 'LEAGUE_OF_LEGENDS', 'OVER_UNDER', 200, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (6, 'LEAGUE_OF_LEGENDS', 'OVER_UNDER', 500, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (8, 'LEAGUE_OF_LEGENDS', 'OVER_UNDER', 1000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (10, 'LEAGUE_OF_LEGENDS', 'OVER_UNDER', 2000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (6, 'LEAGUE_OF_LEGENDS', 'FANTASY', 5000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default'),
+ (10, 'LEAGUE_OF_LEGENDS', 'FANTASY', 10000, 0, 1, 10, 1, 1, 1, 500000000, 'STR', 'autogenerated default')
+ON CONFLICT (leg_count, game, type) DO UPDATE SET
+  guarantee = EXCLUDED.guarantee,
+  carry_in = EXCLUDED.carry_in,
+  allocation = EXCLUDED.allocation,
+  unit_value = EXCLUDED.unit_value,
+  min_unit_per_line = EXCLUDED.min_unit_per_line,
+  max_unit_per_line = EXCLUDED.max_unit_per_line,
+  min_unit_per_ticket = EXCLUDED.min_unit_per_ticket,
+  max_unit_per_ticket = EXCLUDED.max_unit_per_ticket,
+  currency = EXCLUDED.currency,
+  note = EXCLUDED.note;
@@ -0,0 +1,17 @@
+-- Over/under thresholds per game and match format. Keyed on
+-- over_under_defaults_uniqueness_on_game_match_format.
+INSERT INTO over_under_defaults (game, match_format, even_threshold, favored_threshold, note)
+VALUES
+ ('DOTA_2', 'bo2', 94.5, 47.5, 'autogenerated default'),
+ ('DOTA_2', 'bo3', 141.5, 94.5, 'autogenerated default'),
+ ('DOTA_2', 'bo5', 188.5, 141.5, 'autogenerated default'),
+ ('COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'bo2', 320.5, 160.5, 'autogenerated default'),
+ ('COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'bo3', 480.5, 320.5, 'autogenerated default'),
+ ('COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'bo5', 800.5, 640.5, 'autogenerated default'),
+ ('LEAGUE_OF_LEGENDS', 'bo2', 49.5, 24.5, 'autogenerated default'),
+ ('LEAGUE_OF_LEGENDS', 'bo3', 73.5, 49.5, 'autogenerated default'),
+ ('LEAGUE_OF_LEGENDS', 'bo5', 122.5, 98.5, 'autogenerated default')
+ON CONFLICT (game, match_format) DO UPDATE SET
+  even_threshold = EXCLUDED.even_threshold,
+  favored_threshold = EXCLUDED.favored_threshold,
+  note = EXCLUDED.note;
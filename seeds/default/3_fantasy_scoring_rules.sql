@@ -0,0 +1,19 @@
+-- Fantasy points per unit of each player statistic in Match.statistics.
+-- Keyed on fantasy_scoring_rules_uniqueness_on_game_stat.
+INSERT INTO fantasy_scoring_rules (game, stat, points, note)
+VALUES
+ ('COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'kills', 2, 'autogenerated default'),
+ ('COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'assists', 1, 'autogenerated default'),
+ ('COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'deaths', -1, 'autogenerated default'),
+ ('COUNTER_STRIKE_GLOBAL_OFFENSIVE', 'headshots', 0.5, 'autogenerated default'),
+ ('DOTA_2', 'kills', 3, 'autogenerated default'),
+ ('DOTA_2', 'assists', 2, 'autogenerated default'),
+ ('DOTA_2', 'deaths', -1, 'autogenerated default'),
+ ('DOTA_2', 'lastHits', 0.02, 'autogenerated default'),
+ ('LEAGUE_OF_LEGENDS', 'kills', 3, 'autogenerated default'),
+ ('LEAGUE_OF_LEGENDS', 'assists', 2, 'autogenerated default'),
+ ('LEAGUE_OF_LEGENDS', 'deaths', -1, 'autogenerated default'),
+ ('LEAGUE_OF_LEGENDS', 'creepScore', 0.02, 'autogenerated default')
+ON CONFLICT (game, stat) DO UPDATE SET
+  points = EXCLUDED.points,
+  note = EXCLUDED.note;
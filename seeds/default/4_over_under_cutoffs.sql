@@ -0,0 +1,10 @@
+-- Win probability at or above which a match counts as favored and its
+-- over/under leg takes the favored threshold. Keyed on game.
+INSERT INTO over_under_cutoffs (game, favored_probability, note)
+VALUES
+ ('DOTA_2', 0.65, 'autogenerated default'),
+ ('COUNTER_STRIKE_GLOBAL_OFFENSIVE', 0.65, 'autogenerated default'),
+ ('LEAGUE_OF_LEGENDS', 0.65, 'autogenerated default')
+ON CONFLICT (game) DO UPDATE SET
+  favored_probability = EXCLUDED.favored_probability,
+  note = EXCLUDED.note;
@@ -0,0 +1,11 @@
+-- What settlement does with legs whose match was voided, keyed on pool
+-- type. A pool with more than abandon_above void legs is abandoned.
+INSERT INTO pool_void_rules (type, rule, abandon_above, note)
+VALUES
+ ('H2H', 'ALL_WIN', 2, 'autogenerated default'),
+ ('OVER_UNDER', 'ALL_WIN', 2, 'autogenerated default'),
+ ('FANTASY', 'ALL_WIN', 2, 'autogenerated default')
+ON CONFLICT (type) DO UPDATE SET
+  rule = EXCLUDED.rule,
+  abandon_above = EXCLUDED.abandon_above,
+  note = EXCLUDED.note;
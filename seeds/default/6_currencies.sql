@@ -0,0 +1,13 @@
+-- Decimal places and rounding of amounts per currency. Unit values must
+-- be rounded this way; converted amounts are rounded by the target
+-- currency.
+INSERT INTO currencies (code, places, mode, note)
+VALUES
+ ('STR', 7, 'DOWN', 'autogenerated default'),
+ ('EUR', 2, 'HALF_UP', 'autogenerated default'),
+ ('USD', 2, 'HALF_UP', 'autogenerated default'),
+ ('GBP', 2, 'HALF_UP', 'autogenerated default')
+ON CONFLICT (code) DO UPDATE SET
+  places = EXCLUDED.places,
+  mode = EXCLUDED.mode,
+  note = EXCLUDED.note;
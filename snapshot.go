@@ -0,0 +1,304 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// bookkeepingTables are owned by the migrator and left out of snapshots.
+var bookkeepingTables = map[string]bool{
+	"schema_migrations":           true,
+	"schema_migrations_checksums": true,
+}
+
+// ColumnSnapshot describes a table column as reported by information_schema.
+type ColumnSnapshot struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	Default  string `json:"default,omitempty"`
+}
+
+// IndexSnapshot is an index and its CREATE INDEX definition.
+type IndexSnapshot struct {
+	Name       string `json:"name"`
+	Definition string `json:"definition"`
+}
+
+// ConstraintSnapshot is a table constraint and its definition.
+type ConstraintSnapshot struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Definition string `json:"definition"`
+}
+
+// TableSnapshot is the shape of a single table.
+type TableSnapshot struct {
+	Name        string               `json:"name"`
+	Columns     []ColumnSnapshot     `json:"columns"`
+	Indexes     []IndexSnapshot      `json:"indexes"`
+	Constraints []ConstraintSnapshot `json:"constraints"`
+}
+
+// SchemaSnapshot is the canonical shape of the public schema. Tables,
+// indexes and constraints are sorted by name, columns by position.
+type SchemaSnapshot struct {
+	Extensions []string         `json:"extensions"`
+	Tables     []*TableSnapshot `json:"tables"`
+}
+
+// takeSnapshot reads the current shape of the public schema.
+func takeSnapshot(db *sql.DB) (*SchemaSnapshot, error) {
+	snapshot := &SchemaSnapshot{Extensions: []string{}, Tables: []*TableSnapshot{}}
+	tables := map[string]*TableSnapshot{}
+	table := func(name string) *TableSnapshot {
+		t, ok := tables[name]
+		if !ok {
+			t = &TableSnapshot{
+				Name:        name,
+				Columns:     []ColumnSnapshot{},
+				Indexes:     []IndexSnapshot{},
+				Constraints: []ConstraintSnapshot{},
+			}
+			tables[name] = t
+			snapshot.Tables = append(snapshot.Tables, t)
+		}
+		return t
+	}
+
+	err := eachRow(db, `
+		SELECT extname FROM pg_extension WHERE extname <> 'plpgsql' ORDER BY extname`,
+		func(rows *sql.Rows) error {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			snapshot.Extensions = append(snapshot.Extensions, name)
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "reading extensions")
+	}
+
+	err = eachRow(db, `
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE'
+		ORDER BY table_name`,
+		func(rows *sql.Rows) error {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			if !bookkeepingTables[name] {
+				table(name)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "reading tables")
+	}
+
+	err = eachRow(db, `
+		SELECT c.table_name, c.column_name,
+			CASE WHEN c.data_type IN ('USER-DEFINED', 'ARRAY') THEN c.udt_name ELSE c.data_type END,
+			c.is_nullable = 'YES', COALESCE(c.column_default, '')
+		FROM information_schema.columns c
+		JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE c.table_schema = 'public' AND t.table_type = 'BASE TABLE'
+		ORDER BY c.table_name, c.ordinal_position`,
+		func(rows *sql.Rows) error {
+			var tableName string
+			var column ColumnSnapshot
+			if err := rows.Scan(&tableName, &column.Name, &column.Type, &column.Nullable, &column.Default); err != nil {
+				return err
+			}
+			if !bookkeepingTables[tableName] {
+				t := table(tableName)
+				t.Columns = append(t.Columns, column)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "reading columns")
+	}
+
+	err = eachRow(db, `
+		SELECT tablename, indexname, indexdef FROM pg_indexes
+		WHERE schemaname = 'public'
+		ORDER BY tablename, indexname`,
+		func(rows *sql.Rows) error {
+			var tableName string
+			var index IndexSnapshot
+			if err := rows.Scan(&tableName, &index.Name, &index.Definition); err != nil {
+				return err
+			}
+			if !bookkeepingTables[tableName] {
+				t := table(tableName)
+				t.Indexes = append(t.Indexes, index)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "reading indexes")
+	}
+
+	err = eachRow(db, `
+		SELECT tc.table_name, tc.constraint_name, tc.constraint_type, pg_get_constraintdef(con.oid)
+		FROM information_schema.table_constraints tc
+		JOIN pg_namespace ns ON ns.nspname = tc.constraint_schema
+		JOIN pg_class cl ON cl.relname = tc.table_name AND cl.relnamespace = ns.oid
+		JOIN pg_constraint con ON con.conname = tc.constraint_name AND con.conrelid = cl.oid
+		WHERE tc.table_schema = 'public'
+		ORDER BY tc.table_name, tc.constraint_name`,
+		func(rows *sql.Rows) error {
+			var tableName string
+			var constraint ConstraintSnapshot
+			if err := rows.Scan(&tableName, &constraint.Name, &constraint.Type, &constraint.Definition); err != nil {
+				return err
+			}
+			if !bookkeepingTables[tableName] {
+				t := table(tableName)
+				t.Constraints = append(t.Constraints, constraint)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, "reading constraints")
+	}
+
+	sort.Slice(snapshot.Tables, func(i, j int) bool {
+		return snapshot.Tables[i].Name < snapshot.Tables[j].Name
+	})
+	return snapshot, nil
+}
+
+func eachRow(db *sql.DB, query string, scan func(rows *sql.Rows) error) error {
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// lines flattens the snapshot into one sortable line per schema object.
+func (s *SchemaSnapshot) lines() []string {
+	var lines []string
+	for _, extension := range s.Extensions {
+		lines = append(lines, fmt.Sprintf("extension %s", extension))
+	}
+	for _, t := range s.Tables {
+		lines = append(lines, fmt.Sprintf("table %s", t.Name))
+		for _, c := range t.Columns {
+			nullable := "NOT NULL"
+			if c.Nullable {
+				nullable = "NULL"
+			}
+			line := fmt.Sprintf("column %s.%s %s %s", t.Name, c.Name, c.Type, nullable)
+			if c.Default != "" {
+				line += " DEFAULT " + c.Default
+			}
+			lines = append(lines, line)
+		}
+		for _, i := range t.Indexes {
+			lines = append(lines, fmt.Sprintf("index %s.%s %s", t.Name, i.Name, i.Definition))
+		}
+		for _, c := range t.Constraints {
+			lines = append(lines, fmt.Sprintf("constraint %s.%s %s %s", t.Name, c.Name, c.Type, c.Definition))
+		}
+	}
+	return lines
+}
+
+// diffSnapshots lists the objects only in want ("-") or only in got ("+").
+func diffSnapshots(want, got *SchemaSnapshot) []string {
+	wantLines := map[string]bool{}
+	for _, line := range want.lines() {
+		wantLines[line] = true
+	}
+	gotLines := map[string]bool{}
+	for _, line := range got.lines() {
+		gotLines[line] = true
+	}
+
+	var diff []string
+	for line := range wantLines {
+		if !gotLines[line] {
+			diff = append(diff, "- "+line)
+		}
+	}
+	for line := range gotLines {
+		if !wantLines[line] {
+			diff = append(diff, "+ "+line)
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i][2:] < diff[j][2:]
+	})
+	return diff
+}
+
+// WriteJSON writes the snapshot as indented JSON.
+func (s *SchemaSnapshot) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding snapshot")
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// WriteSQL writes the snapshot as DDL. Indexes that back a primary key or
+// unique constraint and NOT NULL constraints are folded into their owners.
+func (s *SchemaSnapshot) WriteSQL(w io.Writer) error {
+	var b strings.Builder
+	for _, extension := range s.Extensions {
+		fmt.Fprintf(&b, "CREATE EXTENSION IF NOT EXISTS %q;\n", extension)
+	}
+	for _, t := range s.Tables {
+		fmt.Fprintf(&b, "\nCREATE TABLE %s (\n", t.Name)
+		for i, c := range t.Columns {
+			fmt.Fprintf(&b, "    %s %s", c.Name, strings.ToUpper(c.Type))
+			if !c.Nullable {
+				b.WriteString(" NOT NULL")
+			}
+			if c.Default != "" {
+				fmt.Fprintf(&b, " DEFAULT %s", c.Default)
+			}
+			if i < len(t.Columns)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(");\n")
+
+		backing := map[string]bool{}
+		for _, c := range t.Constraints {
+			if strings.HasPrefix(c.Definition, "NOT NULL") {
+				continue
+			}
+			if c.Type == "PRIMARY KEY" || c.Type == "UNIQUE" {
+				backing[c.Name] = true
+			}
+			fmt.Fprintf(&b, "ALTER TABLE %s ADD CONSTRAINT %s %s;\n", t.Name, c.Name, c.Definition)
+		}
+		for _, i := range t.Indexes {
+			if !backing[i.Name] {
+				fmt.Fprintf(&b, "%s;\n", i.Definition)
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}